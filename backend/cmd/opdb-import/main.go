@@ -0,0 +1,68 @@
+// Command opdb-import loads OPDB export files into the machine table so
+// machines can be looked up without calling the OPDB API.
+//
+// Usage:
+//
+//	go run ./cmd/opdb-import [-db pinball.db] export.json [groups.json ...]
+//
+// Files are the JSON arrays returned by OPDB's machine and group export
+// endpoints. Re-running the import upserts, so it is safe to load a newer
+// export over an existing database.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"backend/models"
+	"backend/services"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	dbPath := flag.String("db", "pinball.db", "path to the SQLite database")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-db path] export.json [more.json ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	db, err := gorm.Open(sqlite.Open(*dbPath), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Machine{}); err != nil {
+		log.Fatalf("Failed to migrate machine table: %v", err)
+	}
+
+	var entries []services.OPDBExportEntry
+	for _, path := range flag.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", path, err)
+		}
+		parsed, err := services.ParseOPDBExport(file)
+		file.Close()
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", path, err)
+		}
+		log.Printf("Read %d records from %s", len(parsed), path)
+		entries = append(entries, parsed...)
+	}
+
+	result, err := services.ImportOPDBExport(db, entries)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	fmt.Printf("added: %d, updated: %d, unchanged: %d\n", result.Added, result.Updated, result.Unchanged)
+}
@@ -26,7 +26,7 @@ const docTemplate = `{
     "paths": {
         "/auth/login": {
             "post": {
-                "description": "Authenticate user with email and password",
+                "description": "Authenticate user with email and password. The response carries a short-lived access token and a refresh token for getting the next one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -76,6 +76,56 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Revoke the access token used for this request. When a refresh token is given, it and every token issued from the same login are revoked too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Logged out"
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/me": {
             "get": {
                 "security": [
@@ -113,9 +163,61 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be used once; using one again revokes every token issued from the same login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh an access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New token pair",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired or reused refresh token, or the user no longer exists",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/signup": {
             "post": {
-                "description": "Create a new user account with email and password",
+                "description": "Create a new user account with email and password. The response carries a short-lived access token and a refresh token for getting the next one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -167,7 +269,7 @@ const docTemplate = `{
         },
         "/leagues": {
             "get": {
-                "description": "Get a list of all pinball leagues",
+                "description": "Get a page of pinball leagues, optionally filtered by location, owner or name prefix",
                 "produces": [
                     "application/json"
                 ],
@@ -175,13 +277,53 @@ const docTemplate = `{
                     "leagues"
                 ],
                 "summary": "List all leagues",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only leagues at this location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only leagues owned by this user",
+                        "name": "ownerID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only leagues whose name starts with this, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, each optionally prefixed with - for descending: name, location, dateCreated",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Leagues per page, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "List of leagues",
+                        "description": "Page of leagues",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/handlers.ListResponse"
+                                    "$ref": "#/definitions/handlers.PagedResponse"
                                 },
                                 {
                                     "type": "object",
@@ -197,6 +339,12 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid filter, sort or page",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -204,9 +352,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/leagues/create": {
+            },
             "post": {
                 "security": [
                     {
@@ -319,6 +465,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -326,18 +478,21 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/leagues/{leagueID}/players": {
-            "get": {
-                "description": "Get a list of all players in a specific league",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a league along with its seasons, events, players, machine bank and staff. Leagues with any recorded results cannot be deleted. Only the league owner may delete a league.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "leagues"
                 ],
-                "summary": "List players in a league",
+                "summary": "Delete a league",
                 "parameters": [
                     {
                         "type": "string",
@@ -348,26 +503,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of players",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handlers.ListResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/handlers.PlayerResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                    "204": {
+                        "description": "League deleted successfully"
                     },
                     "400": {
                         "description": "Invalid league ID",
@@ -375,6 +512,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "League has recorded results",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -382,16 +543,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/leagues/{leagueID}/players/ifpa": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Add multiple players to a league using their IFPA numbers",
+                "description": "Change a league's name or location. Omitted fields are left unchanged.",
                 "consumes": [
                     "application/json"
                 ],
@@ -401,7 +560,7 @@ const docTemplate = `{
                 "tags": [
                     "leagues"
                 ],
-                "summary": "Add players to league by IFPA numbers",
+                "summary": "Update a league",
                 "parameters": [
                     {
                         "type": "string",
@@ -411,18 +570,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "IFPA numbers",
+                        "description": "League changes",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/models.UpdateLeagueRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Players added successfully",
+                        "description": "League updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -432,10 +591,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/handlers.PlayerResponse"
-                                            }
+                                            "$ref": "#/definitions/handlers.LeagueResponse"
                                         }
                                     }
                                 }
@@ -454,6 +610,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -463,16 +631,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/leagues/{leagueID}/seasons": {
+        "/leagues/{leagueID}/machines": {
             "get": {
-                "description": "Get a list of all seasons for a specific league",
+                "description": "Get the machines in a league's bank, including whether each is in order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "seasons"
+                    "machines"
                 ],
-                "summary": "List seasons for a league",
+                "summary": "List a league's machines",
                 "parameters": [
                     {
                         "type": "string",
@@ -484,7 +652,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of seasons",
+                        "description": "League machines",
                         "schema": {
                             "allOf": [
                                 {
@@ -496,7 +664,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/handlers.SeasonResponse"
+                                                "$ref": "#/definitions/handlers.LeagueMachineResponse"
                                             }
                                         }
                                     }
@@ -510,6 +678,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -524,7 +698,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new season for a specific league",
+                "description": "Add a machine to a league's bank by its OPDB ID. Machine details are fetched from OPDB if they are not already cached.",
                 "consumes": [
                     "application/json"
                 ],
@@ -532,9 +706,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seasons"
+                    "machines"
                 ],
-                "summary": "Create a new season",
+                "summary": "Add a machine to a league",
                 "parameters": [
                     {
                         "type": "string",
@@ -544,18 +718,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Season details",
+                        "description": "Machine to add",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/models.AddLeagueMachineRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Season created successfully",
+                        "description": "Machine added successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -565,7 +739,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.SeasonResponse"
+                                            "$ref": "#/definitions/handlers.LeagueMachineResponse"
                                         }
                                     }
                                 }
@@ -573,7 +747,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or league ID",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -584,25 +758,60 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League or machine not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Machine is already in the league's bank",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "OPDB unreachable and machine not cached",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "OPDB integration is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/leagues/{leagueID}/seasons/{seasonID}/events": {
-            "get": {
-                "description": "Get a list of all events for a specific season",
+        "/leagues/{leagueID}/machines/{machineID}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove a machine from a league's bank. Machines still attached to an event that is not complete cannot be removed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "machines"
                 ],
-                "summary": "List events for a season",
+                "summary": "Remove a machine from a league",
                 "parameters": [
                     {
                         "type": "string",
@@ -613,36 +822,42 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Season ID",
-                        "name": "seasonID",
+                        "description": "Machine ID",
+                        "name": "machineID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of events",
+                    "204": {
+                        "description": "Machine removed successfully"
+                    },
+                    "400": {
+                        "description": "Invalid league ID or machine ID",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handlers.ListResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/handlers.EventResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid league ID or season ID",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Machine not in the league's bank",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Machine is attached to an event that is not complete",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -655,13 +870,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Create a new event for a specific season",
+                "description": "Mark a machine in the league's bank in or out of order, or change its notes. Marking a machine out of order moves any unplayed games scheduled on it, in events that are not complete, onto the event's other machines.",
                 "consumes": [
                     "application/json"
                 ],
@@ -669,9 +884,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "machines"
                 ],
-                "summary": "Create a new event",
+                "summary": "Update a league machine",
                 "parameters": [
                     {
                         "type": "string",
@@ -682,24 +897,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Season ID",
-                        "name": "seasonID",
+                        "description": "Machine ID",
+                        "name": "machineID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Event details",
+                        "description": "Machine changes",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/models.UpdateLeagueMachineRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Event created successfully",
+                    "200": {
+                        "description": "Machine updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -709,7 +924,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.EventResponse"
+                                            "$ref": "#/definitions/handlers.LeagueMachineResponse"
                                         }
                                     }
                                 }
@@ -717,7 +932,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body, league ID or machine ID",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -728,6 +943,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Machine not in the league's bank",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An event has no other machine to take the machine's games",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -737,16 +970,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}": {
+        "/leagues/{leagueID}/members": {
             "get": {
-                "description": "Get detailed information about a specific event",
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "List the owner, admins and scorekeepers of a league. Only league staff may see the list.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "events"
+                    "members"
                 ],
-                "summary": "Get event by ID",
+                "summary": "List league staff",
                 "parameters": [
                     {
                         "type": "string",
@@ -754,25 +992,100 @@ const docTemplate = `{
                         "name": "leagueID",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "League staff",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.MemberResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not on the league staff",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Give an existing user an admin or scorekeeper role in a league, looked up by email. Admins may add scorekeepers; only the owner may add admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Add a league staff member",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Season ID",
-                        "name": "seasonID",
+                        "description": "League ID",
+                        "name": "leagueID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "eventID",
-                        "in": "path",
-                        "required": true
+                        "description": "Member details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddLeagueMemberRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Event details",
+                    "201": {
+                        "description": "Member added successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -782,7 +1095,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.EventResponse"
+                                            "$ref": "#/definitions/handlers.MemberResponse"
                                         }
                                     }
                                 }
@@ -790,13 +1103,31 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid league ID, season ID, or event ID",
+                        "description": "Invalid request body or league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to grant this role",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Event not found",
+                        "description": "League or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already on the league staff",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -810,49 +1141,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/machines/{opdb_id}": {
-            "get": {
-                "description": "Get machine details from OPDB API and cache in database",
-                "consumes": [
-                    "application/json"
+        "/leagues/{leagueID}/members/{userID}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
+                "description": "Remove an admin or scorekeeper from a league. Admins may remove scorekeepers; only the owner may remove admins. The owner cannot be removed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "machines"
+                    "members"
                 ],
-                "summary": "Get machine details from OPDB",
+                "summary": "Remove a league staff member",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "OPDB ID",
-                        "name": "opdb_id",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "Member removed successfully"
+                    },
+                    "400": {
+                        "description": "Invalid league or user ID",
                         "schema": {
-                            "$ref": "#/definitions/models.Machine"
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to remove this member",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "League or member not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -860,40 +1209,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/seasons/{seasonID}": {
+        "/leagues/{leagueID}/players": {
             "get": {
-                "description": "Get detailed information about a specific season",
+                "description": "Get a page of the players in a specific league, optionally filtered by name prefix. Archived players are left out unless includeArchived is set.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "seasons"
+                    "leagues"
                 ],
-                "summary": "Get a season by ID",
+                "summary": "List players in a league",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Season ID",
-                        "name": "seasonID",
+                        "description": "League ID",
+                        "name": "leagueID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include archived players",
+                        "name": "includeArchived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only players whose name starts with this, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, each optionally prefixed with - for descending: name, ifpaRank, ifpaPoints",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Players per page, at most 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Season details",
+                        "description": "Page of players",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SeasonResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.PagedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.PlayerResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid season ID",
+                        "description": "Invalid league ID, filter, sort or page",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Season not found",
+                        "description": "League not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -905,210 +1301,4656 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "handlers.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user": {
-                    "$ref": "#/definitions/handlers.UserResponse"
-                }
-            }
-        },
-        "handlers.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Add a player to a league by name, for guests and players without an IFPA number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Create a player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePlayerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Player created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.PlayerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/players/ifpa": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Look up each IFPA number concurrently and add the players found to the league in a single transaction. Every number gets its own result: ADDED, ALREADY_PRESENT, NOT_FOUND or ERROR, so one failed lookup does not stop the rest. Duplicate numbers are reported once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leagues"
+                ],
+                "summary": "Add players to league by IFPA numbers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "IFPA numbers",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddPlayersByIFPARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result for each IFPA number",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.IFPAImportResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "IFPA integration is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/players/ifpa-sync": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fetch the current IFPA rank, WPPR points and rating for every player in the league with an IFPA number and store them on the player. Requests are rate limited and temporary failures are retried; players who still fail are listed in the result and keep their previous figures.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Sync IFPA rankings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sync finished",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.IFPASyncResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A sync is already running for this league",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "IFPA integration is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/players/{playerID}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a player and remove them from any events and groups they are in. Players who have recorded results cannot be deleted; archive them instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Delete a player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Player deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid player ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Player has recorded results",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Change a player's name or IFPA number, or archive them. Omitted fields are left unchanged. Archived players keep their results but are hidden from the league's player list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Update a player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePlayerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Player updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.PlayerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or player ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/players/{playerID}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Move every event attendance, group place, game result, finals result and event score of the source player onto the player in the path, then delete the source player. The surviving player keeps their name and takes the source's IFPA number if they have none. Players who both have results in the same event, or who sit in different groups of the same event, cannot be merged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Merge two players",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the player that survives the merge",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player to merge away",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergePlayersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Players merged successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.PlayerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or player ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Both players have results, or are in different groups, in the same event",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons": {
+            "get": {
+                "description": "Get a page of the seasons in a specific league, optionally filtered by name prefix or whether they have finals",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "List seasons for a league",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only seasons whose name starts with this, ignoring case",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only seasons with, or without, finals",
+                        "name": "hasFinals",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, each optionally prefixed with - for descending: name, dateCreated, startDate",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Seasons per page, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of seasons",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.PagedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.SeasonResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID, filter, sort or page",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new season for a specific league. Counting games must be positive. The optional start and end dates limit when the season's events can be held.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Create a new season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Season details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSeasonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Season created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.SeasonResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}": {
+            "get": {
+                "description": "Get detailed information about a specific season",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get a season by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Season details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SeasonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid season ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete a season along with its events. Seasons with any recorded results cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Delete a season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Season deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid season ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Season has recorded results",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Change a season's name, counting games, finals, point distribution or start and end dates. Omitted fields are left unchanged. The point distribution cannot change once an event has been completed, since completed events keep the points they were scored with, and the dates cannot move past any of the season's events.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Update a season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Season changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateSeasonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Season updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.SeasonResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or season ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Change conflicts with completed events, finals or event dates",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events": {
+            "get": {
+                "description": "Get a page of the events in a specific season, optionally filtered by date range, completion or whether they are finals",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "List events for a season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events on or after this RFC3339 timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events on or before this RFC3339 timestamp",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only completed, or uncompleted, events",
+                        "name": "isComplete",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only finals, or regular, events",
+                        "name": "isFinals",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields, each optionally prefixed with - for descending: date, name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Events per page, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of events",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.PagedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.EventResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID, season ID, filter, sort or page",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League or season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Create a new event for a specific season. The date must fall within the season's start and end dates, and a season can have only one finals event. The seeding method defaults to AVERAGE and the group ordering to SEEDED.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Create a new event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Event created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.EventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or date outside the season",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League or season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Season already has a finals event",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}": {
+            "get": {
+                "description": "Get detailed information about a specific event, including the points each player has earned so far",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get event by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.EventDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid league ID, season ID, or event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "League, season or event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Delete an event along with its groups and schedule. Events with recorded games, finals or completed results cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Delete an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Event deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event has recorded results",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Change an event's name, date, winners group, seeding method or group ordering. Omitted fields are left unchanged. The date must fall within the season's start and end dates. Completed events must be reopened before they can be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Update an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.EventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or event ID, or date outside the season",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Check that every scheduled game has a result, store each player's final points, mark the event complete and update the season standings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Complete an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event completed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.EventDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event already complete or results missing",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/finals": {
+            "get": {
+                "description": "Get the finals bracket for an event with every round played so far and the current ranking",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finals"
+                ],
+                "summary": "Get the finals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Finals bracket",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.FinalsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found or finals not started",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Qualify the top players from the season standings and build the first round of the finals bracket. Only allowed once every regular season event is complete.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finals"
+                ],
+                "summary": "Start the finals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Finals format and number of qualifiers",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StartFinalsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Finals started successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.FinalsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, event ID, or qualifier count",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Finals already started or regular season not complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/finals/matches/{matchID}/results": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Record where each player finished in a finals match. When every match in the round is complete the next round is built, or the finals are completed after the last match.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finals"
+                ],
+                "summary": "Record a finals match result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Match ID",
+                        "name": "matchID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Finishing positions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FinalsMatchResultRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result recorded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.FinalsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, event ID, or match ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event, finals or match not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Match already played or not in the current round",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/games": {
+            "get": {
+                "description": "Get all recorded games and their results for a specific event",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "List games for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of games",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.GameResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Record the finishing position (and optionally the score) of each player in a group on a machine",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Record a game result",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Game details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateGameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Game recorded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.GameResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is already complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/games/{gameID}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove a game and its results from an event that is not yet complete",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Delete a recorded game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Game deleted"
+                    },
+                    "400": {
+                        "description": "Invalid event ID or game ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is already complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/groups": {
+            "get": {
+                "description": "Get the groups for a specific event and the players in each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "List groups for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of groups",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.GroupResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Replace the event's groups with the given arrangement. Every checked-in player must be in exactly one group of three or four, and at most one group can be the winners group. Only allowed before any game has been recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Update groups for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group arrangement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateGroupsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Groups updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.GroupResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, event ID or group arrangement",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event has already started",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/groups/generate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Seed the event's players and split them into groups of four (with threes for the remainder), replacing any existing groups",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "groups"
+                ],
+                "summary": "Generate groups for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Groups generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.GroupResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID or player count",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event has already started",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/machines": {
+            "get": {
+                "description": "Get the machines from the league's bank that are attached to an event, including whether each is in order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "machines"
+                ],
+                "summary": "List an event's machines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event machines",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.LeagueMachineResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Attach machines from the league's bank to an event. Every machine must be in the bank and in order. Machines already attached are left as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "machines"
+                ],
+                "summary": "Add machines to an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Machines to attach",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AttachMachinesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Machines attached successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.LeagueMachineResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, or machines not in order in the league's bank",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/machines/{machineID}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Detach a machine from an event. Unplayed games scheduled on it move to the event's other in-order machines, in the same round where a machine is free or in a later round otherwise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "machines"
+                ],
+                "summary": "Remove a machine from an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Machine ID",
+                        "name": "machineID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Machine removed successfully"
+                    },
+                    "400": {
+                        "description": "Invalid event ID or machine ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found, or machine not attached to the event",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is complete, or no other machine can take the machine's games",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/players": {
+            "get": {
+                "description": "Get the players checked in to an event and the group each has been placed in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "List players checked in to an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checked-in players",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.CheckInResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Check players from the event's league in to the event. Players already checked in are left as they are. If groups have already been generated, new players are placed the same way as a single late check-in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Check several players in to an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Players to check in",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Players checked in successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.CheckInResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, finals event, or players not in the event's league",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/players/{playerID}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Check a player from the event's league in to the event. If groups have already been generated the player joins the smallest group with room, other than the winners group; if every group is full they are left unplaced until the groups are regenerated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Check a player in to an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Player was already checked in",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.CheckInResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Player checked in successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.CheckInResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or finals event",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found, or player not found in the event's league",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Remove a player from an event and from their group. Players who already have results in the event cannot be removed. Groups left short may need regenerating.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "Remove a player from an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Player ID",
+                        "name": "playerID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Player removed successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID or finals event",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found or player not checked in",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is complete or player has results",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/reopen": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Reopen a completed event so its results can be corrected. Only the league owner may reopen an event, and the reason is recorded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Reopen an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for reopening",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReopenEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event reopened successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.EventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the league owner",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is not complete",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/schedule": {
+            "get": {
+                "description": "Get the machine each group plays in each round, and which of those games have been recorded",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Get the schedule for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event schedule",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.ScheduledGameResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Assign each group a different machine in every round, replacing any existing schedule. Groups must be generated first, and only the event's machines that are in order in the league's bank are used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "Generate the schedule for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenerateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Schedule generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.ScheduledGameResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, event ID, or not enough machines",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient league role",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event has already started",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/seeding": {
+            "get": {
+                "description": "Get the players checked in to an event in seeding order. The order is derived from the event's seeding method and stored seed, so it can be reproduced.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get event seeding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event seeding",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.SeedingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/standings": {
+            "get": {
+                "description": "Get ranked player standings for a season from its completed events, counting each player's best CountingGames event results. Ties are broken by event wins, then best finish, then registration order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get season standings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Season standings",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.StandingResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid season ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leagues/{leagueID}/seasons/{seasonID}/unattended_players": {
+            "get": {
+                "description": "Get the league's active players who have not been checked in to any event of the season yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attendance"
+                ],
+                "summary": "List players who have not attended a season",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "League ID",
+                        "name": "leagueID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Season ID",
+                        "name": "seasonID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Players yet to attend",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.PlayerResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid season ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Season not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/machines/search": {
+            "get": {
+                "description": "Search OPDB and the locally cached machines by name, merged and ranked by relevance. Local matches are still returned if OPDB cannot be reached.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "machines"
+                ],
+                "summary": "Search machines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results (default 20, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/services.MachineSearchResult"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/machines/{opdb_id}": {
+            "get": {
+                "description": "Get machine details from OPDB API and cache in database. A stale cached copy is returned if OPDB cannot be reached.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "machines"
+                ],
+                "summary": "Get machine details from OPDB",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "OPDB ID",
+                        "name": "opdb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Machine"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Machine not found in OPDB",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "OPDB unreachable and machine not cached",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "OPDB integration is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "VALIDATION_FAILED"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                }
+            }
+        },
+        "handlers.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:15:00Z"
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/handlers.UserResponse"
+                }
+            }
+        },
+        "handlers.CheckInResponse": {
+            "type": "object",
+            "properties": {
+                "groupID": {
+                    "type": "integer"
+                },
+                "player": {
+                    "$ref": "#/definitions/models.Player"
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/handlers.APIError"
+                }
+            }
+        },
+        "handlers.EventDetailResponse": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "groupOrdering": {
+                    "$ref": "#/definitions/models.GroupOrdering"
+                },
+                "hasWinnersGroup": {
+                    "type": "boolean"
+                },
+                "isComplete": {
+                    "type": "boolean"
+                },
+                "isFinals": {
+                    "type": "boolean"
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Machine"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Player"
+                    }
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PlayerEventPoints"
+                    }
+                },
+                "reopenings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventReopening"
+                    }
+                },
+                "season": {
+                    "$ref": "#/definitions/models.Season"
+                },
+                "seasonID": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "description": "Drives every random draw for the event so it can be reproduced",
+                    "type": "integer"
+                },
+                "seedingMethod": {
+                    "$ref": "#/definitions/models.SeedingMethod"
+                }
+            }
+        },
+        "handlers.EventResponse": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "groupOrdering": {
+                    "$ref": "#/definitions/models.GroupOrdering"
+                },
+                "hasWinnersGroup": {
+                    "type": "boolean"
+                },
+                "isComplete": {
+                    "type": "boolean"
+                },
+                "isFinals": {
+                    "type": "boolean"
+                },
+                "machines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Machine"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Player"
+                    }
+                },
+                "reopenings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventReopening"
+                    }
+                },
+                "season": {
+                    "$ref": "#/definitions/models.Season"
+                },
+                "seasonID": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "description": "Drives every random draw for the event so it can be reproduced",
+                    "type": "integer"
+                },
+                "seedingMethod": {
+                    "$ref": "#/definitions/models.SeedingMethod"
+                }
+            }
+        },
+        "handlers.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "name"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "name is required"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "handlers.FinalsResponse": {
+            "type": "object",
+            "properties": {
+                "currentRound": {
+                    "type": "integer"
+                },
+                "eventID": {
+                    "type": "integer"
+                },
+                "format": {
+                    "$ref": "#/definitions/models.FinalsFormat"
+                },
+                "isComplete": {
+                    "type": "boolean"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FinalsMatch"
+                    }
+                },
+                "qualifierCount": {
+                    "type": "integer"
+                },
+                "ranking": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.FinalsRankingRow"
+                    }
+                }
+            }
+        },
+        "handlers.GameResponse": {
+            "type": "object",
+            "properties": {
+                "eventID": {
+                    "type": "integer"
+                },
+                "groupID": {
+                    "type": "integer"
+                },
+                "machine": {
+                    "$ref": "#/definitions/models.Machine"
+                },
+                "machineID": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GameResult"
+                    }
+                },
+                "round": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.GroupResponse": {
+            "type": "object",
+            "properties": {
+                "eventID": {
+                    "type": "integer"
+                },
+                "isWinnersGroup": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Player"
+                    }
+                }
+            }
+        },
+        "handlers.IFPAImportResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "ifpaNumber": {
+                    "type": "integer"
+                },
+                "player": {
+                    "$ref": "#/definitions/models.Player"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.IFPAImportStatus"
+                }
+            }
+        },
+        "handlers.LeagueMachineResponse": {
+            "type": "object",
+            "properties": {
+                "isActive": {
+                    "description": "Out-of-order machines are inactive and cannot be scheduled",
+                    "type": "boolean"
+                },
+                "leagueID": {
+                    "type": "integer"
+                },
+                "machine": {
+                    "$ref": "#/definitions/models.Machine"
+                },
+                "machineID": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LeagueResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string",
-                    "example": "Invalid request body"
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "dateCreated": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "location": {
+                    "type": "string",
+                    "example": "123 Main St"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Downtown Pinball League"
+                },
+                "owner": {
+                    "$ref": "#/definitions/models.SwaggerUser"
+                },
+                "ownerID": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.ListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {}
+            }
+        },
+        "handlers.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "leagueID": {
+                    "type": "integer"
+                },
+                "role": {
+                    "$ref": "#/definitions/models.LeagueRole"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PagedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/handlers.Pagination"
+                }
+            }
+        },
+        "handlers.Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.PlayerResponse": {
+            "type": "object",
+            "properties": {
+                "ifpaFetchedAt": {
+                    "description": "When the IFPA figures were last fetched, nil if never",
+                    "type": "string"
+                },
+                "ifpaNumber": {
+                    "type": "string"
+                },
+                "ifpaPoints": {
+                    "description": "Current WPPR points",
+                    "type": "number"
+                },
+                "ifpaRank": {
+                    "description": "Current WPPR world rank, 0 if unranked",
+                    "type": "integer"
+                },
+                "ifpaRating": {
+                    "description": "Glicko rating",
+                    "type": "number"
+                },
+                "isArchived": {
+                    "description": "Archived players keep their results but are hidden from the league's player list",
+                    "type": "boolean"
+                },
+                "league": {
+                    "$ref": "#/definitions/models.League"
+                },
+                "leagueID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ScheduledGameResponse": {
+            "type": "object",
+            "properties": {
+                "eventID": {
+                    "type": "integer"
+                },
+                "gameID": {
+                    "type": "integer"
+                },
+                "group": {
+                    "$ref": "#/definitions/models.Group"
+                },
+                "groupID": {
+                    "type": "integer"
+                },
+                "machine": {
+                    "$ref": "#/definitions/models.Machine"
+                },
+                "machineID": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SeasonResponse": {
+            "type": "object",
+            "properties": {
+                "countingGames": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "dateCreated": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2024-06-30T23:59:59Z"
+                },
+                "eventCount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "hasFinals": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "league": {
+                    "$ref": "#/definitions/models.SwaggerLeague"
+                },
+                "leagueID": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Spring 2024"
+                },
+                "pointDistribution": {
+                    "$ref": "#/definitions/models.PointDistributionMap"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "handlers.SeedingResponse": {
+            "type": "object",
+            "properties": {
+                "eventID": {
+                    "type": "integer"
+                },
+                "method": {
+                    "$ref": "#/definitions/models.SeedingMethod"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SeededPlayer"
+                    }
+                },
+                "seed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.StandingResponse": {
+            "type": "object",
+            "properties": {
+                "bestFinish": {
+                    "type": "integer"
+                },
+                "countedEvents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PlayerEventResult"
+                    }
+                },
+                "droppedEvents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PlayerEventResult"
+                    }
+                },
+                "eventWins": {
+                    "type": "integer"
+                },
+                "eventsPlayed": {
+                    "type": "integer"
+                },
+                "playerID": {
+                    "type": "integer"
+                },
+                "playerName": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "tiebreak": {
+                    "description": "Tiebreak names the rule that separated this player from a player with the same total",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:15:00Z"
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "handlers.UserResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.AddLeagueMachineRequest": {
+            "type": "object",
+            "required": [
+                "opdbID"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string"
+                },
+                "opdbID": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.EventResponse": {
+        "models.AddLeagueMemberRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
             "properties": {
-                "completedAt": {
+                "email": {
                     "type": "string"
                 },
+                "role": {
+                    "enum": [
+                        "ADMIN",
+                        "SCOREKEEPER"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LeagueRole"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.AddPlayersByIFPARequest": {
+            "type": "object",
+            "required": [
+                "ifpaNumbers"
+            ],
+            "properties": {
+                "ifpaNumbers": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.AttachMachinesRequest": {
+            "type": "object",
+            "required": [
+                "machineIDs"
+            ],
+            "properties": {
+                "machineIDs": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.CheckInRequest": {
+            "type": "object",
+            "required": [
+                "playerIDs"
+            ],
+            "properties": {
+                "playerIDs": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.CreateEventRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "name"
+            ],
+            "properties": {
                 "date": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "2024-03-01T19:00:00Z"
                 },
                 "groupOrdering": {
-                    "$ref": "#/definitions/models.GroupOrdering"
+                    "enum": [
+                        "RANDOM",
+                        "SEEDED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GroupOrdering"
+                        }
+                    ]
                 },
                 "hasWinnersGroup": {
                     "type": "boolean"
                 },
-                "isComplete": {
-                    "type": "boolean"
-                },
                 "isFinals": {
                     "type": "boolean"
                 },
-                "machines": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Machine"
-                    }
-                },
                 "name": {
                     "type": "string"
                 },
-                "players": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Player"
-                    }
-                },
-                "season": {
-                    "$ref": "#/definitions/models.Season"
-                },
-                "seasonID": {
+                "seed": {
                     "type": "integer"
                 },
                 "seedingMethod": {
-                    "$ref": "#/definitions/models.SeedingMethod"
+                    "enum": [
+                        "AVERAGE",
+                        "RANK",
+                        "RANDOM",
+                        "IFPA_RANK"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeedingMethod"
+                        }
+                    ]
                 }
             }
         },
-        "handlers.LeagueResponse": {
+        "models.CreateGameRequest": {
             "type": "object",
+            "required": [
+                "machineID",
+                "results"
+            ],
             "properties": {
-                "createdAt": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "groupID": {
+                    "type": "integer"
                 },
-                "dateCreated": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "machineID": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "results": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.GameResultRequest"
+                    }
                 },
-                "location": {
-                    "type": "string",
-                    "example": "123 Main St"
+                "round": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.CreatePlayerRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "ifpaNumber": {
+                    "type": "string"
                 },
                 "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateSeasonRequest": {
+            "type": "object",
+            "required": [
+                "countingGames",
+                "name"
+            ],
+            "properties": {
+                "countingGames": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "endDate": {
                     "type": "string",
-                    "example": "Downtown Pinball League"
+                    "example": "2024-06-30T23:59:59Z"
                 },
-                "owner": {
-                    "$ref": "#/definitions/models.SwaggerUser"
+                "hasFinals": {
+                    "type": "boolean"
                 },
-                "ownerID": {
-                    "type": "integer",
-                    "example": 1
+                "name": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "pointDistribution": {
+                    "$ref": "#/definitions/models.PointDistributionMap"
+                },
+                "startDate": {
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
-        "handlers.ListResponse": {
+        "models.EventReopening": {
             "type": "object",
             "properties": {
-                "data": {}
+                "eventID": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reopenedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "userID": {
+                    "type": "integer"
+                }
             }
         },
-        "handlers.PlayerResponse": {
+        "models.FinalsFormat": {
+            "type": "string",
+            "enum": [
+                "PAPA",
+                "HEAD_TO_HEAD"
+            ],
+            "x-enum-varnames": [
+                "FinalsFormatPAPA",
+                "FinalsFormatHeadToHead"
+            ]
+        },
+        "models.FinalsMatch": {
             "type": "object",
             "properties": {
-                "ifpaNumber": {
-                    "type": "string"
+                "finalsID": {
+                    "type": "integer"
                 },
-                "league": {
-                    "$ref": "#/definitions/models.League"
+                "isComplete": {
+                    "type": "boolean"
                 },
-                "leagueID": {
+                "number": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FinalsMatchPlayer"
+                    }
+                },
+                "round": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.SeasonResponse": {
+        "models.FinalsMatchPlayer": {
             "type": "object",
             "properties": {
-                "countingGames": {
-                    "type": "integer",
-                    "example": 5
+                "matchID": {
+                    "type": "integer"
                 },
-                "createdAt": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "player": {
+                    "$ref": "#/definitions/models.Player"
                 },
-                "dateCreated": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "playerID": {
+                    "type": "integer"
                 },
-                "eventCount": {
-                    "type": "integer",
-                    "example": 0
+                "position": {
+                    "type": "integer"
                 },
-                "hasFinals": {
-                    "type": "boolean",
-                    "example": false
+                "seed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FinalsMatchResultRequest": {
+            "type": "object",
+            "required": [
+                "results"
+            ],
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/models.FinalsPositionRequest"
+                    }
+                }
+            }
+        },
+        "models.FinalsPositionRequest": {
+            "type": "object",
+            "required": [
+                "playerID",
+                "position"
+            ],
+            "properties": {
+                "playerID": {
+                    "type": "integer"
                 },
-                "id": {
+                "position": {
                     "type": "integer",
-                    "example": 1
-                },
-                "league": {
-                    "$ref": "#/definitions/models.SwaggerLeague"
+                    "minimum": 1
+                }
+            }
+        },
+        "models.GameResult": {
+            "type": "object",
+            "properties": {
+                "gameID": {
+                    "type": "integer"
                 },
-                "leagueID": {
-                    "type": "integer",
-                    "example": 1
+                "player": {
+                    "$ref": "#/definitions/models.Player"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Spring 2024"
+                "playerID": {
+                    "type": "integer"
                 },
-                "pointDistribution": {
-                    "$ref": "#/definitions/models.PointDistributionMap"
+                "position": {
+                    "type": "integer"
                 },
-                "updatedAt": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "score": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.UserResponse": {
+        "models.GameResultRequest": {
             "type": "object",
+            "required": [
+                "playerID",
+                "position"
+            ],
             "properties": {
-                "createdAt": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "playerID": {
+                    "type": "integer"
                 },
-                "firstName": {
-                    "type": "string",
-                    "example": "John"
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "id": {
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GenerateScheduleRequest": {
+            "type": "object",
+            "required": [
+                "gamesPerGroup"
+            ],
+            "properties": {
+                "gamesPerGroup": {
                     "type": "integer",
-                    "example": 1
+                    "minimum": 1
+                }
+            }
+        },
+        "models.Group": {
+            "type": "object",
+            "properties": {
+                "eventID": {
+                    "type": "integer"
                 },
-                "lastName": {
-                    "type": "string",
-                    "example": "Doe"
+                "isWinnersGroup": {
+                    "type": "boolean"
                 },
-                "updatedAt": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "number": {
+                    "type": "integer"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Player"
+                    }
                 }
             }
         },
@@ -1116,11 +5958,47 @@ const docTemplate = `{
             "type": "string",
             "enum": [
                 "RANDOM",
+                "SEEDED",
                 "SEEDED"
             ],
             "x-enum-varnames": [
                 "GroupOrderingRandom",
-                "GroupOrderingSeeded"
+                "GroupOrderingSeeded",
+                "DefaultGroupOrdering"
+            ]
+        },
+        "models.GroupRequest": {
+            "type": "object",
+            "required": [
+                "playerIDs"
+            ],
+            "properties": {
+                "isWinnersGroup": {
+                    "type": "boolean"
+                },
+                "playerIDs": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "minItems": 3,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.IFPAImportStatus": {
+            "type": "string",
+            "enum": [
+                "ADDED",
+                "ALREADY_PRESENT",
+                "NOT_FOUND",
+                "ERROR"
+            ],
+            "x-enum-varnames": [
+                "IFPAImportAdded",
+                "IFPAImportAlreadyPresent",
+                "IFPAImportNotFound",
+                "IFPAImportError"
             ]
         },
         "models.League": {
@@ -1143,6 +6021,27 @@ const docTemplate = `{
                 }
             }
         },
+        "models.LeagueRole": {
+            "type": "string",
+            "enum": [
+                "OWNER",
+                "ADMIN",
+                "SCOREKEEPER"
+            ],
+            "x-enum-varnames": [
+                "LeagueRoleOwner",
+                "LeagueRoleAdmin",
+                "LeagueRoleScorekeeper"
+            ]
+        },
+        "models.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Machine": {
             "type": "object",
             "properties": {
@@ -1162,6 +6061,9 @@ const docTemplate = `{
                 "is_pinball": {
                     "type": "boolean"
                 },
+                "manufacturer": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -1175,7 +6077,17 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "year": {
-                    "description": "Manufacturer  string    ` + "`" + `json:\"manufacturer\"` + "`" + `",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MergePlayersRequest": {
+            "type": "object",
+            "required": [
+                "sourcePlayerID"
+            ],
+            "properties": {
+                "sourcePlayerID": {
                     "type": "integer"
                 }
             }
@@ -1183,9 +6095,29 @@ const docTemplate = `{
         "models.Player": {
             "type": "object",
             "properties": {
+                "ifpaFetchedAt": {
+                    "description": "When the IFPA figures were last fetched, nil if never",
+                    "type": "string"
+                },
                 "ifpaNumber": {
                     "type": "string"
                 },
+                "ifpaPoints": {
+                    "description": "Current WPPR points",
+                    "type": "number"
+                },
+                "ifpaRank": {
+                    "description": "Current WPPR world rank, 0 if unranked",
+                    "type": "integer"
+                },
+                "ifpaRating": {
+                    "description": "Glicko rating",
+                    "type": "number"
+                },
+                "isArchived": {
+                    "description": "Archived players keep their results but are hidden from the league's player list",
+                    "type": "boolean"
+                },
                 "league": {
                     "$ref": "#/definitions/models.League"
                 },
@@ -1206,6 +6138,28 @@ const docTemplate = `{
                 }
             }
         },
+        "models.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReopenEventRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Season": {
             "type": "object",
             "properties": {
@@ -1218,6 +6172,10 @@ const docTemplate = `{
                 "dateCreated": {
                     "type": "string"
                 },
+                "endDate": {
+                    "description": "Events must fall on or before this, when set",
+                    "type": "string"
+                },
                 "eventCount": {
                     "type": "integer"
                 },
@@ -1235,6 +6193,10 @@ const docTemplate = `{
                 },
                 "pointDistribution": {
                     "$ref": "#/definitions/models.PointDistributionMap"
+                },
+                "startDate": {
+                    "description": "Events must fall on or after this, when set",
+                    "type": "string"
                 }
             }
         },
@@ -1244,15 +6206,41 @@ const docTemplate = `{
                 "AVERAGE",
                 "RANK",
                 "RANDOM",
-                "IFPA_RANK"
+                "IFPA_RANK",
+                "AVERAGE"
             ],
             "x-enum-varnames": [
                 "SeedingMethodAverage",
                 "SeedingMethodRank",
                 "SeedingMethodRandom",
-                "SeedingMethodIFPARank"
+                "SeedingMethodIFPARank",
+                "DefaultSeedingMethod"
             ]
         },
+        "models.StartFinalsRequest": {
+            "type": "object",
+            "required": [
+                "format",
+                "qualifiers"
+            ],
+            "properties": {
+                "format": {
+                    "enum": [
+                        "PAPA",
+                        "HEAD_TO_HEAD"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FinalsFormat"
+                        }
+                    ]
+                },
+                "qualifiers": {
+                    "type": "integer",
+                    "minimum": 2
+                }
+            }
+        },
         "models.SwaggerLeague": {
             "type": "object",
             "properties": {
@@ -1318,6 +6306,124 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateEventRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "groupOrdering": {
+                    "enum": [
+                        "RANDOM",
+                        "SEEDED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GroupOrdering"
+                        }
+                    ]
+                },
+                "hasWinnersGroup": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "seedingMethod": {
+                    "enum": [
+                        "AVERAGE",
+                        "RANK",
+                        "RANDOM",
+                        "IFPA_RANK"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeedingMethod"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.UpdateGroupsRequest": {
+            "type": "object",
+            "required": [
+                "groups"
+            ],
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.GroupRequest"
+                    }
+                }
+            }
+        },
+        "models.UpdateLeagueMachineRequest": {
+            "type": "object",
+            "properties": {
+                "isActive": {
+                    "type": "boolean"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateLeagueRequest": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "models.UpdatePlayerRequest": {
+            "type": "object",
+            "properties": {
+                "ifpaNumber": {
+                    "type": "string"
+                },
+                "isArchived": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "models.UpdateSeasonRequest": {
+            "type": "object",
+            "properties": {
+                "countingGames": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "hasFinals": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "pointDistribution": {
+                    "$ref": "#/definitions/models.PointDistributionMap"
+                },
+                "startDate": {
+                    "type": "string"
+                }
+            }
+        },
         "models.User": {
             "type": "object",
             "properties": {
@@ -1337,6 +6443,143 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "services.FinalsRankingRow": {
+            "type": "object",
+            "properties": {
+                "matchesPlayed": {
+                    "type": "integer"
+                },
+                "playerID": {
+                    "type": "integer"
+                },
+                "playerName": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "roundReached": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.IFPASyncFailure": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "ifpaNumber": {
+                    "type": "string"
+                },
+                "playerID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.IFPASyncResult": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.IFPASyncFailure"
+                    }
+                },
+                "synced": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.MachineSearchResult": {
+            "type": "object",
+            "properties": {
+                "machineID": {
+                    "description": "Set when the machine is already cached locally",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opdbID": {
+                    "type": "string"
+                },
+                "relevance": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "\"local\", \"opdb\" or \"both\"",
+                    "type": "string"
+                },
+                "supplementary": {
+                    "description": "Manufacturer and year, as OPDB displays them",
+                    "type": "string"
+                }
+            }
+        },
+        "services.PlayerEventPoints": {
+            "type": "object",
+            "properties": {
+                "gamesPlayed": {
+                    "type": "integer"
+                },
+                "place": {
+                    "type": "integer"
+                },
+                "playerID": {
+                    "type": "integer"
+                },
+                "playerName": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                }
+            }
+        },
+        "services.PlayerEventResult": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer"
+                },
+                "eventName": {
+                    "type": "string"
+                },
+                "place": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "number"
+                }
+            }
+        },
+        "services.SeededPlayer": {
+            "type": "object",
+            "properties": {
+                "player": {
+                    "$ref": "#/definitions/models.Player"
+                },
+                "ranked": {
+                    "type": "boolean"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1353,7 +6596,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
-	BasePath:         "/api",
+	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "Pinball League API",
 	Description:      "A Pinball League Management System API",
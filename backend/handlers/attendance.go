@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+	"backend/services"
+)
+
+type AttendanceHandler struct {
+	db *gorm.DB
+}
+
+func NewAttendanceHandler(db *gorm.DB) *AttendanceHandler {
+	return &AttendanceHandler{db: db}
+}
+
+// ListEventPlayers handles listing the players checked in to an event
+// @Summary List players checked in to an event
+// @Description Get the players checked in to an event and the group each has been placed in
+// @Tags attendance
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=[]CheckInResponse} "Checked-in players"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/players [get]
+func (h *AttendanceHandler) ListEventPlayers(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+
+	var players []models.Player
+	if err := h.db.Model(event).Order("name").Association("Players").Find(&players); err != nil {
+		log.Printf("ListEventPlayers error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch players"))
+		return
+	}
+
+	placements, err := groupPlacements(h.db, event.ID)
+	if err != nil {
+		log.Printf("ListEventPlayers error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch groups"))
+		return
+	}
+
+	checkIns := make([]CheckInResponse, len(players))
+	for i, player := range players {
+		checkIns[i] = CheckInResponse{Player: player, GroupID: placements[player.ID]}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": checkIns,
+	})
+}
+
+// CheckInPlayer handles checking a single player in to an event
+// @Summary Check a player in to an event
+// @Description Check a player from the event's league in to the event. If groups have already been generated the player joins the smallest group with room, other than the winners group; if every group is full they are left unplaced until the groups are regenerated.
+// @Tags attendance
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param playerID path string true "Player ID"
+// @Success 200 {object} ListResponse{data=CheckInResponse} "Player was already checked in"
+// @Success 201 {object} ListResponse{data=CheckInResponse} "Player checked in successfully"
+// @Failure 400 {object} ErrorResponse "Invalid ID or finals event"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found, or player not found in the event's league"
+// @Failure 409 {object} ErrorResponse "Event is complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/players/{playerID} [post]
+func (h *AttendanceHandler) CheckInPlayer(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+	event := c.MustGet("event").(*models.Event)
+	player := c.MustGet("player").(*models.Player)
+	if err := checkAttendanceOpen(event); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var checkIns []CheckInResponse
+	var added int
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		checkIns, added, err = checkInPlayers(tx, event, league.ID, []uint{player.ID})
+		return err
+	})
+	if err != nil {
+		respondWithError(c, "CheckInPlayer", "Failed to update attendance", err)
+		return
+	}
+
+	status := http.StatusOK
+	if added > 0 {
+		status = http.StatusCreated
+		log.Printf("CheckInPlayer success - Player %d checked in to event %d", player.ID, event.ID)
+	}
+	c.JSON(status, gin.H{
+		"data": checkIns[0],
+	})
+}
+
+// CheckInPlayers handles checking several players in to an event at once
+// @Summary Check several players in to an event
+// @Description Check players from the event's league in to the event. Players already checked in are left as they are. If groups have already been generated, new players are placed the same way as a single late check-in.
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.CheckInRequest true "Players to check in"
+// @Success 200 {object} ListResponse{data=[]CheckInResponse} "Players checked in successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body, finals event, or players not in the event's league"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event is complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/players [post]
+func (h *AttendanceHandler) CheckInPlayers(c *gin.Context) {
+	var req models.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("CheckInPlayers error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	league := c.MustGet("league").(*models.League)
+	event := c.MustGet("event").(*models.Event)
+	if err := checkAttendanceOpen(event); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var checkIns []CheckInResponse
+	var added int
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		checkIns, added, err = checkInPlayers(tx, event, league.ID, req.PlayerIDs)
+		return err
+	})
+	if err != nil {
+		respondWithError(c, "CheckInPlayers", "Failed to update attendance", err)
+		return
+	}
+
+	log.Printf("CheckInPlayers success - Checked in %d new players to event %d", added, event.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": checkIns,
+	})
+}
+
+// CheckOutPlayer handles removing a player from an event
+// @Summary Remove a player from an event
+// @Description Remove a player from an event and from their group. Players who already have results in the event cannot be removed. Groups left short may need regenerating.
+// @Tags attendance
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param playerID path string true "Player ID"
+// @Success 204 "Player removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid ID or finals event"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found or player not checked in"
+// @Failure 409 {object} ErrorResponse "Event is complete or player has results"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/players/{playerID} [delete]
+func (h *AttendanceHandler) CheckOutPlayer(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+	playerID := c.MustGet("player").(*models.Player).ID
+	if err := checkAttendanceOpen(event); err != nil {
+		c.Error(err)
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var player models.Player
+		if err := tx.Model(event).Where("players.id = ?", playerID).Association("Players").Find(&player); err != nil {
+			return err
+		}
+		if player.ID == 0 {
+			return NewAPIError(http.StatusNotFound, CodePlayerNotFound, "Player is not checked in to this event")
+		}
+
+		var resultCount int64
+		if err := tx.Model(&models.GameResult{}).
+			Joins("JOIN games ON games.id = game_results.game_id").
+			Where("games.event_id = ? AND games.deleted_at IS NULL AND game_results.player_id = ?", event.ID, playerID).
+			Count(&resultCount).Error; err != nil {
+			return err
+		}
+		if resultCount > 0 {
+			return NewAPIError(http.StatusConflict, CodeConflict, "Players with results in this event cannot be removed from it")
+		}
+
+		var groups []models.Group
+		if err := tx.Where("event_id = ?", event.ID).Find(&groups).Error; err != nil {
+			return err
+		}
+		for i := range groups {
+			if err := tx.Model(&groups[i]).Association("Players").Delete(&player); err != nil {
+				return err
+			}
+		}
+		return tx.Model(event).Association("Players").Delete(&player)
+	})
+	if err != nil {
+		respondWithError(c, "CheckOutPlayer", "Failed to update attendance", err)
+		return
+	}
+
+	log.Printf("CheckOutPlayer success - Player %d removed from event %d", playerID, event.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// ListUnattendedPlayers handles listing the league players who have not attended a season
+// @Summary List players who have not attended a season
+// @Description Get the league's active players who have not been checked in to any event of the season yet
+// @Tags attendance
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Success 200 {object} ListResponse{data=[]PlayerResponse} "Players yet to attend"
+// @Failure 400 {object} ErrorResponse "Invalid season ID"
+// @Failure 404 {object} ErrorResponse "Season not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/unattended_players [get]
+func (h *AttendanceHandler) ListUnattendedPlayers(c *gin.Context) {
+	season := c.MustGet("season").(*models.Season)
+
+	attended := h.db.Table("event_players").
+		Select("event_players.player_id").
+		Joins("JOIN events ON events.id = event_players.event_id").
+		Where("events.season_id = ? AND events.deleted_at IS NULL", season.ID)
+
+	var players []models.Player
+	if err := h.db.Where("league_id = ? AND is_archived = ? AND id NOT IN (?)", season.LeagueID, false, attended).
+		Order("name").
+		Find(&players).Error; err != nil {
+		log.Printf("ListUnattendedPlayers error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch players"))
+		return
+	}
+
+	log.Printf("ListUnattendedPlayers success - %d players yet to attend season %d", len(players), season.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": players,
+	})
+}
+
+// checkAttendanceOpen reports whether an event's attendance can be changed.
+// Completed events are locked, and finals events take their players from the
+// standings when the finals start.
+func checkAttendanceOpen(event *models.Event) error {
+	if event.IsComplete {
+		return NewAPIError(http.StatusConflict, CodeEventComplete, "Event is complete")
+	}
+	if event.IsFinals {
+		return NewAPIError(http.StatusBadRequest, CodeBadRequest, "Players are added to a finals event when the finals start")
+	}
+	return nil
+}
+
+// checkInPlayers adds players from the event's league to the event, skipping
+// any already checked in. When the event already has groups, each new player
+// is placed in a group with room. It returns every requested player with
+// their group, in request order, and the number of players newly checked in.
+func checkInPlayers(tx *gorm.DB, event *models.Event, leagueID uint, playerIDs []uint) ([]CheckInResponse, int, error) {
+	var players []models.Player
+	if err := tx.Where("id IN ? AND league_id = ?", playerIDs, leagueID).Find(&players).Error; err != nil {
+		return nil, 0, err
+	}
+	playersByID := make(map[uint]models.Player, len(players))
+	for _, player := range players {
+		playersByID[player.ID] = player
+	}
+	for _, playerID := range playerIDs {
+		if _, ok := playersByID[playerID]; !ok {
+			return nil, 0, NewAPIError(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("Player %d is not a player in this event's league", playerID))
+		}
+	}
+
+	var attending []uint
+	if err := tx.Table("event_players").Where("event_id = ?", event.ID).Pluck("player_id", &attending).Error; err != nil {
+		return nil, 0, err
+	}
+	checkedIn := make(map[uint]bool, len(attending))
+	for _, playerID := range attending {
+		checkedIn[playerID] = true
+	}
+
+	var groups []models.Group
+	if err := tx.Where("event_id = ?", event.ID).Preload("Players").Order("number").Find(&groups).Error; err != nil {
+		return nil, 0, err
+	}
+	placements := placementsByPlayer(groups)
+
+	checkIns := make([]CheckInResponse, 0, len(playerIDs))
+	added := 0
+	for _, playerID := range playerIDs {
+		player := playersByID[playerID]
+		if !checkedIn[playerID] {
+			if err := tx.Model(event).Omit("Players.*").Association("Players").Append(&player); err != nil {
+				return nil, 0, err
+			}
+			checkedIn[playerID] = true
+			added++
+		}
+
+		if placements[playerID] == nil && len(groups) > 0 {
+			if index := services.LateArrivalGroup(groups); index >= 0 {
+				group := &groups[index]
+				if err := tx.Model(group).Omit("Players.*").Association("Players").Append(&player); err != nil {
+					return nil, 0, err
+				}
+				placements[playerID] = &group.ID
+			}
+		}
+
+		checkIns = append(checkIns, CheckInResponse{Player: player, GroupID: placements[playerID]})
+	}
+
+	return checkIns, added, nil
+}
+
+// groupPlacements maps each grouped player in an event to their group ID
+func groupPlacements(db *gorm.DB, eventID uint) (map[uint]*uint, error) {
+	var groups []models.Group
+	if err := db.Where("event_id = ?", eventID).Preload("Players").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return placementsByPlayer(groups), nil
+}
+
+func placementsByPlayer(groups []models.Group) map[uint]*uint {
+	placements := make(map[uint]*uint)
+	for i := range groups {
+		for _, player := range groups[i].Players {
+			placements[player.ID] = &groups[i].ID
+		}
+	}
+	return placements
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -9,19 +10,24 @@ import (
 	"gorm.io/gorm"
 
 	"backend/models"
+	"backend/services"
 )
 
 type AuthHandler struct {
-	db *gorm.DB
+	db           *gorm.DB
+	tokenService *services.TokenService
 }
 
-func NewAuthHandler(db *gorm.DB) *AuthHandler {
-	return &AuthHandler{db: db}
+func NewAuthHandler(db *gorm.DB, tokenService *services.TokenService) *AuthHandler {
+	return &AuthHandler{
+		db:           db,
+		tokenService: tokenService,
+	}
 }
 
 // Signup handles user registration
 // @Summary Register a new user
-// @Description Create a new user account with email and password
+// @Description Create a new user account with email and password. The response carries a short-lived access token and a refresh token for getting the next one.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -41,7 +47,7 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Printf("Signup error - Invalid request body: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Error(newValidationError(err))
 		return
 	}
 
@@ -50,7 +56,7 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 	result := h.db.Where("email = ?", req.Email).First(&existingUser)
 	if result.Error == nil {
 		log.Printf("Signup error - User already exists: %s", req.Email)
-		c.JSON(http.StatusConflict, gin.H{"error": "User already exists"})
+		c.Error(NewAPIError(http.StatusConflict, CodeAlreadyExists, "User already exists"))
 		return
 	}
 
@@ -58,7 +64,7 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		log.Printf("Signup error - Password hashing failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create user"))
 		return
 	}
 
@@ -72,15 +78,15 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 	// Create user
 	if err := h.db.Create(&user).Error; err != nil {
 		log.Printf("Signup error - Database error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create user"))
 		return
 	}
 
-	// Generate JWT token
-	token, err := GenerateToken(user.ID)
+	// Generate access and refresh tokens
+	tokens, err := h.issueTokens(user.ID, "")
 	if err != nil {
 		log.Printf("Signup error - Token generation failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to generate token"))
 		return
 	}
 
@@ -92,13 +98,15 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 			"firstName": user.FirstName,
 			"lastName":  user.LastName,
 		},
-		"token": token,
+		"token":        tokens.Token,
+		"refreshToken": tokens.RefreshToken,
+		"expiresAt":    tokens.ExpiresAt,
 	})
 }
 
 // Login handles user authentication
 // @Summary Login user
-// @Description Authenticate user with email and password
+// @Description Authenticate user with email and password. The response carries a short-lived access token and a refresh token for getting the next one.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -116,7 +124,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Printf("Login error - Invalid request body: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Error(newValidationError(err))
 		return
 	}
 
@@ -128,10 +136,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			log.Printf("Login error - User not found: %s", req.Email)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+			c.Error(NewAPIError(http.StatusUnauthorized, CodeInvalidCredentials, "Invalid credentials"))
 		} else {
 			log.Printf("Login error - Database error: %v", result.Error)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to login"})
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to login"))
 		}
 		return
 	}
@@ -141,17 +149,17 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
 		log.Printf("Login error - Invalid password for user: %s", req.Email)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeInvalidCredentials, "Invalid credentials"))
 		return
 	}
 
 	log.Printf("Password verified for user: %s", req.Email)
 
-	// Generate JWT token
-	token, err := GenerateToken(user.ID)
+	// Generate access and refresh tokens
+	tokens, err := h.issueTokens(user.ID, "")
 	if err != nil {
 		log.Printf("Login error - Token generation failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to generate token"))
 		return
 	}
 
@@ -163,7 +171,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			"firstName": user.FirstName,
 			"lastName":  user.LastName,
 		},
-		"token": token,
+		"token":        tokens.Token,
+		"refreshToken": tokens.RefreshToken,
+		"expiresAt":    tokens.ExpiresAt,
 	})
 }
 
@@ -181,14 +191,14 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
 		log.Printf("GetCurrentUser error - No user ID in context")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeUnauthorized, "Unauthorized"))
 		return
 	}
 
 	var user models.User
 	if err := h.db.First(&user, userID).Error; err != nil {
 		log.Printf("GetCurrentUser error - User not found: %v", err)
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		c.Error(NewAPIError(http.StatusNotFound, CodeUserNotFound, "User not found"))
 		return
 	}
 
@@ -200,3 +210,125 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 		"lastName":  user.LastName,
 	})
 }
+
+// Refresh exchanges a refresh token for a new token pair
+// @Summary Refresh an access token
+// @Description Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be used once; using one again revokes every token issued from the same login.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse "New token pair"
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Invalid, expired or reused refresh token, or the user no longer exists"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(newValidationError(err))
+		return
+	}
+
+	refreshToken, userID, err := h.tokenService.RotateRefreshToken(req.RefreshToken)
+	switch {
+	case errors.Is(err, services.ErrRefreshTokenReused):
+		log.Printf("Refresh error - Refresh token reused, revoked its family")
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeInvalidToken, "Invalid refresh token"))
+		return
+	case errors.Is(err, services.ErrInvalidRefreshToken):
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeInvalidToken, "Invalid refresh token"))
+		return
+	case err != nil:
+		log.Printf("Refresh error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to refresh token"))
+		return
+	}
+
+	// The account may have been deleted since the token was issued
+	if err := h.db.First(&models.User{}, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if revokeErr := h.tokenService.RevokeRefreshToken(userID, refreshToken); revokeErr != nil {
+				log.Printf("Refresh error - Failed to revoke refresh tokens of deleted user %d: %v", userID, revokeErr)
+			} else {
+				log.Printf("Refresh error - User %d no longer exists, revoked its refresh tokens", userID)
+			}
+			c.Error(NewAPIError(http.StatusUnauthorized, CodeInvalidToken, "Invalid refresh token"))
+			return
+		}
+		log.Printf("Refresh error - Failed to load user %d: %v", userID, err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to refresh token"))
+		return
+	}
+
+	tokens, err := h.issueTokens(userID, refreshToken)
+	if err != nil {
+		log.Printf("Refresh error - Token generation failed: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to generate token"))
+		return
+	}
+
+	log.Printf("Refresh success - Tokens refreshed for user %d", userID)
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout revokes the caller's access token and, when given, refresh token
+// @Summary Logout user
+// @Description Revoke the access token used for this request. When a refresh token is given, it and every token issued from the same login are revoked too.
+// @Tags auth
+// @Accept json
+// @Security Bearer
+// @Param request body models.LogoutRequest false "Refresh token to revoke"
+// @Success 204 "Logged out"
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	claims := c.MustGet("tokenClaims").(*Claims)
+
+	var req models.LogoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(newValidationError(err))
+			return
+		}
+	}
+
+	if err := h.tokenService.RevokeAccessToken(claims.ID, claims.UserID, claims.ExpiresAt.Time); err != nil {
+		log.Printf("Logout error - Failed to revoke access token: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to logout"))
+		return
+	}
+	if req.RefreshToken != "" {
+		if err := h.tokenService.RevokeRefreshToken(claims.UserID, req.RefreshToken); err != nil {
+			log.Printf("Logout error - Failed to revoke refresh token: %v", err)
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to logout"))
+			return
+		}
+	}
+
+	log.Printf("Logout success - User %d logged out", claims.UserID)
+	c.Status(http.StatusNoContent)
+}
+
+// issueTokens creates an access token for the user, paired with the given
+// refresh token or, if there is none, the first token of a new refresh family
+func (h *AuthHandler) issueTokens(userID uint, refreshToken string) (*TokenResponse, error) {
+	accessToken, claims, err := GenerateToken(userID)
+	if err != nil {
+		return nil, err
+	}
+	if refreshToken == "" {
+		refreshToken, err = h.tokenService.IssueRefreshToken(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &TokenResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    claims.ExpiresAt.Time,
+	}, nil
+}
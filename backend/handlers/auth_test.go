@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/models"
+	"backend/services"
+)
+
+func TestLogoutRevokesAccessAndRefreshTokens(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	if err := LoadJWTConfig(); err != nil {
+		t.Fatalf("LoadJWTConfig: %v", err)
+	}
+	db := newTestDB(t, &models.User{}, &models.RefreshToken{}, &models.RevokedAccessToken{})
+	tokenService := services.NewTokenService(db, services.TokenConfig{})
+	handler := NewAuthHandler(db, tokenService)
+
+	router := gin.New()
+	router.Use(ErrorHandler)
+	router.POST("/logout", handler.AuthMiddleware, handler.Logout)
+
+	tokens, err := handler.issueTokens(7, "")
+	if err != nil {
+		t.Fatalf("issueTokens: %v", err)
+	}
+	logout := func() int {
+		request := httptest.NewRequest(http.MethodPost, "/logout", strings.NewReader(`{"refreshToken":"`+tokens.RefreshToken+`"}`))
+		request.Header.Set("Authorization", "Bearer "+tokens.Token)
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	if code := logout(); code != http.StatusNoContent {
+		t.Fatalf("logout status = %d, want 204", code)
+	}
+
+	claims, err := ValidateToken(tokens.Token)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if revoked, err := tokenService.AccessTokenRevoked(claims.ID); err != nil || !revoked {
+		t.Errorf("AccessTokenRevoked = %v, %v; want true", revoked, err)
+	}
+	if code := logout(); code != http.StatusUnauthorized {
+		t.Errorf("request with a logged out token: status = %d, want 401", code)
+	}
+	if _, _, err := tokenService.RotateRefreshToken(tokens.RefreshToken); !errors.Is(err, services.ErrRefreshTokenReused) {
+		t.Errorf("refreshing after logout: error = %v, want ErrRefreshTokenReused", err)
+	}
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+// Authorizer checks that the current user holds a staff role allowing them to
+// change the league a request's resource belongs to
+type Authorizer struct {
+	db *gorm.DB
+}
+
+func NewAuthorizer(db *gorm.DB) *Authorizer {
+	return &Authorizer{db: db}
+}
+
+// RequireLeagueRole returns middleware that rejects the request with 403
+// unless the authenticated user holds at least the given role in the league
+// that the event, season, player or league in the path belongs to. It must
+// run after AuthMiddleware and ResourceLoader.LoadPathResources, which
+// provides the league. The user's role is stored in the context under
+// "leagueRole".
+func (a *Authorizer) RequireLeagueRole(minimum models.LeagueRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.Error(NewAPIError(http.StatusUnauthorized, CodeUnauthorized, "Unauthorized"))
+			c.Abort()
+			return
+		}
+
+		loaded, exists := c.Get("league")
+		if !exists {
+			c.Error(NewAPIError(http.StatusNotFound, CodeLeagueNotFound, "League not found"))
+			c.Abort()
+			return
+		}
+		league := loaded.(*models.League)
+
+		role, err := a.userRole(league, userID.(uint))
+		if err != nil {
+			log.Printf("Authorization error - Database error: %v", err)
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to check permissions"))
+			c.Abort()
+			return
+		}
+		if !role.Includes(minimum) {
+			log.Printf("Authorization denied - User %d has role %q in league %d, needs %q", userID, role, league.ID, minimum)
+			c.Error(NewAPIError(http.StatusForbidden, CodeForbidden, "You do not have permission to modify this league"))
+			c.Abort()
+			return
+		}
+
+		c.Set("leagueRole", role)
+		c.Next()
+	}
+}
+
+// userRole returns the user's role in the league, or an empty role if they
+// are not on its staff. The league's owner always has the owner role.
+func (a *Authorizer) userRole(league *models.League, userID uint) (models.LeagueRole, error) {
+	if league.OwnerID == userID {
+		return models.LeagueRoleOwner, nil
+	}
+
+	var membership models.LeagueMembership
+	err := a.db.Where("league_id = ? AND user_id = ?", league.ID, userID).First(&membership).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return membership.Role, nil
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+// Deleting uses the soft delete provided by gorm.Model, so deleted rows can be
+// recovered. Anything with recorded results blocks the delete instead of being
+// cascaded, so that standings never silently change.
+
+// errHasResults blocks deleting events that have recorded results
+var errHasResults = NewAPIError(http.StatusConflict, CodeConflict, "Events with recorded games, finals or completed results cannot be deleted")
+
+// deleteEvents soft deletes the given events along with their groups and
+// schedule. It fails with errHasResults if any of the events has results.
+func deleteEvents(tx *gorm.DB, eventIDs []uint) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&models.Event{}).
+		Where("id IN ?", eventIDs).
+		Where("is_complete = ? OR id IN (?) OR id IN (?)", true,
+			tx.Model(&models.Game{}).Select("event_id"),
+			tx.Model(&models.Finals{}).Select("event_id")).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errHasResults
+	}
+
+	if err := tx.Where("event_id IN ?", eventIDs).Delete(&models.ScheduledGame{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("event_id IN ?", eventIDs).Delete(&models.Group{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&models.Event{}, eventIDs).Error
+}
+
+// deleteSeasons soft deletes the given seasons and all of their events
+func deleteSeasons(tx *gorm.DB, seasonIDs []uint) error {
+	if len(seasonIDs) == 0 {
+		return nil
+	}
+
+	var eventIDs []uint
+	if err := tx.Model(&models.Event{}).Where("season_id IN ?", seasonIDs).Pluck("id", &eventIDs).Error; err != nil {
+		return err
+	}
+	if err := deleteEvents(tx, eventIDs); err != nil {
+		return err
+	}
+	return tx.Delete(&models.Season{}, seasonIDs).Error
+}
+
+// playerHasResults reports whether a player has played in any game, finals
+// match or completed event
+func playerHasResults(tx *gorm.DB, playerID uint) (bool, error) {
+	for _, model := range []interface{}{&models.GameResult{}, &models.FinalsMatchPlayer{}, &models.EventScore{}} {
+		var count int64
+		if err := tx.Model(model).Where("player_id = ?", playerID).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"backend/models"
+)
+
+// Error codes returned in APIError.Code. Clients match on these rather than
+// on the message, which may change.
+const (
+	CodeValidationFailed    = "VALIDATION_FAILED"
+	CodeInvalidID           = "INVALID_ID"
+	CodeBadRequest          = "BAD_REQUEST"
+	CodeUnauthorized        = "UNAUTHORIZED"
+	CodeInvalidCredentials  = "INVALID_CREDENTIALS"
+	CodeInvalidToken        = "INVALID_TOKEN"
+	CodeForbidden           = "FORBIDDEN"
+	CodeNotFound            = "NOT_FOUND"
+	CodeLeagueNotFound      = "LEAGUE_NOT_FOUND"
+	CodeSeasonNotFound      = "SEASON_NOT_FOUND"
+	CodeEventNotFound       = "EVENT_NOT_FOUND"
+	CodePlayerNotFound      = "PLAYER_NOT_FOUND"
+	CodeGameNotFound        = "GAME_NOT_FOUND"
+	CodeMatchNotFound       = "MATCH_NOT_FOUND"
+	CodeMemberNotFound      = "MEMBER_NOT_FOUND"
+	CodeUserNotFound        = "USER_NOT_FOUND"
+	CodeMachineNotFound     = "MACHINE_NOT_FOUND"
+	CodeFinalsNotStarted    = "FINALS_NOT_STARTED"
+	CodeConflict            = "CONFLICT"
+	CodeAlreadyExists       = "ALREADY_EXISTS"
+	CodeEventComplete       = "EVENT_COMPLETE"
+	CodeFinalsStarted       = "FINALS_STARTED"
+	CodeFinalsEventExists   = "FINALS_EVENT_EXISTS"
+	CodeInternal            = "INTERNAL_ERROR"
+	CodeUpstreamFailed      = "UPSTREAM_FAILED"
+	CodeIntegrationDisabled = "INTEGRATION_DISABLED"
+)
+
+// APIError is the body of every error response
+type APIError struct {
+	Status  int          `json:"-"`
+	Code    string       `json:"code" example:"VALIDATION_FAILED"`
+	Message string       `json:"message" example:"Invalid request body"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// FieldError describes one request field that failed validation
+type FieldError struct {
+	Field   string `json:"field" example:"name"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"name is required"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error *APIError `json:"error"`
+}
+
+// NewAPIError creates an error to pass to c.Error
+func NewAPIError(status int, code, message string) *APIError {
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+// invalidField reports a single field that failed a check made outside the
+// binding validators
+func invalidField(field, rule, message string) *APIError {
+	return &APIError{
+		Status:  http.StatusBadRequest,
+		Code:    CodeValidationFailed,
+		Message: message,
+		Details: []FieldError{{Field: field, Rule: rule, Message: message}},
+	}
+}
+
+// ErrorHandler is middleware that writes the error envelope for the last
+// error a handler attached with c.Error. Errors that are not an APIError are
+// logged and reported as a generic 500 so internal details never reach
+// clients.
+func ErrorHandler(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := c.Errors.Last().Err
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		log.Printf("ErrorHandler error - %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		apiErr = NewAPIError(http.StatusInternalServerError, CodeInternal, "Internal server error")
+	}
+	c.JSON(apiErr.Status, ErrorResponse{Error: apiErr})
+}
+
+// newValidationError converts an error from binding a request into a
+// VALIDATION_FAILED error listing each field that was rejected
+func newValidationError(err error) *APIError {
+	apiErr := NewAPIError(http.StatusBadRequest, CodeValidationFailed, "Invalid request body")
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fieldErr := range validationErrs {
+			apiErr.Details = append(apiErr.Details, FieldError{
+				Field:   fieldPath(fieldErr),
+				Rule:    fieldErr.Tag(),
+				Message: fieldPath(fieldErr) + " " + ruleMessage(fieldErr),
+			})
+		}
+	case errors.As(err, &typeErr):
+		apiErr.Details = []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be a %s", typeErr.Field, jsonTypeName(typeErr.Type)),
+		}}
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		apiErr.Message = "Request body is not valid JSON"
+	}
+	return apiErr
+}
+
+// fieldPath is the field's location in the request, without the request
+// struct's own name: "ifpaNumbers[2]" rather than "AddPlayersByIFPARequest.ifpaNumbers[2]"
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// ruleMessage describes a failed validation rule in words
+func ruleMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	counted := fieldErr.Kind() == reflect.Slice || fieldErr.Kind() == reflect.Map
+	text := fieldErr.Kind() == reflect.String
+
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(param, " ", ", ")
+	case "min":
+		switch {
+		case counted:
+			return "must have at least " + param + " items"
+		case text:
+			return "must be at least " + param + " characters"
+		}
+		return "must be at least " + param
+	case "max":
+		switch {
+		case counted:
+			return "must have at most " + param + " items"
+		case text:
+			return "must be at most " + param + " characters"
+		}
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "gtefield":
+		return "must not be before " + param
+	case "rfc3339":
+		return "must be an RFC3339 timestamp"
+	case "enum":
+		if enum, ok := fieldErr.Value().(models.Enum); ok {
+			return "must be one of: " + strings.Join(enum.Values(), ", ")
+		}
+		return "is not an allowed value"
+	default:
+		return "failed the " + fieldErr.Tag() + " rule"
+	}
+}
+
+// jsonTypeName names a Go type the way a JSON client would think of it
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
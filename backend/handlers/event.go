@@ -1,90 +1,113 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
 	"backend/models"
+	"backend/services"
 )
 
 type EventHandler struct {
-	db *gorm.DB
+	db             *gorm.DB
+	scoringService *services.ScoringService
+	seedingService *services.SeedingService
 }
 
-func NewEventHandler(db *gorm.DB) *EventHandler {
-	return &EventHandler{db: db}
+func NewEventHandler(db *gorm.DB, scoringService *services.ScoringService, seedingService *services.SeedingService) *EventHandler {
+	return &EventHandler{
+		db:             db,
+		scoringService: scoringService,
+		seedingService: seedingService,
+	}
 }
 
 // CreateEvent handles event creation
 // @Summary Create a new event
-// @Description Create a new event for a specific season
+// @Description Create a new event for a specific season. The date must fall within the season's start and end dates, and a season can have only one finals event. The seeding method defaults to AVERAGE and the group ordering to SEEDED.
 // @Tags events
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param leagueID path string true "League ID"
 // @Param seasonID path string true "Season ID"
-// @Param request body object true "Event details" {"name": "string", "date": "string", "isFinals": "boolean", "hasWinnersGroup": "boolean", "seedingMethod": "string", "groupOrdering": "string"}
+// @Param request body models.CreateEventRequest true "Event details"
 // @Success 201 {object} ListResponse{data=EventResponse} "Event created successfully"
-// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 400 {object} ErrorResponse "Invalid request body or date outside the season"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League or season not found"
+// @Failure 409 {object} ErrorResponse "Season already has a finals event"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /leagues/{leagueID}/seasons/{seasonID}/events [post]
 func (h *EventHandler) CreateEvent(c *gin.Context) {
-	leagueID := c.Param("leagueID")
-	seasonID := c.Param("seasonID")
+	season := c.MustGet("season").(*models.Season)
 
-	// Convert IDs to uint
-	_, err := strconv.ParseUint(leagueID, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid league ID"})
+	var req models.CreateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(newValidationError(err))
 		return
 	}
 
-	seasonIDUint, err := strconv.ParseUint(seasonID, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid season ID"})
+	// The rfc3339 binding rule has already checked the format
+	date, _ := time.Parse(time.RFC3339, req.Date)
+	if !season.Contains(date) {
+		c.Error(invalidField("date", "season_window", "date must fall within the season's start and end dates"))
 		return
 	}
 
-	var req struct {
-		Name            string `json:"name" binding:"required"`
-		Date            string `json:"date" binding:"required"`
-		IsFinals        bool   `json:"isFinals"`
-		HasWinnersGroup bool   `json:"hasWinnersGroup"`
-		SeedingMethod   string `json:"seedingMethod"`
-		GroupOrdering   string `json:"groupOrdering"`
+	if req.SeedingMethod == "" {
+		req.SeedingMethod = models.DefaultSeedingMethod
 	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-		return
+	if req.GroupOrdering == "" {
+		req.GroupOrdering = models.DefaultGroupOrdering
 	}
 
-	// Parse date
-	date, err := time.Parse(time.RFC3339, req.Date)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid date format. Use RFC3339 format"})
-		return
+	// Draw a seed now so that every later seeding and grouping of this event can be reproduced
+	seed := rand.Int63()
+	if req.Seed != nil {
+		seed = *req.Seed
 	}
 
 	event := models.Event{
 		Name:            req.Name,
 		Date:            date,
-		SeasonID:        uint(seasonIDUint),
+		SeasonID:        season.ID,
 		IsFinals:        req.IsFinals,
 		IsComplete:      false,
 		HasWinnersGroup: req.HasWinnersGroup,
-		SeedingMethod:   models.SeedingMethod(req.SeedingMethod),
-		GroupOrdering:   models.GroupOrdering(req.GroupOrdering),
+		SeedingMethod:   req.SeedingMethod,
+		GroupOrdering:   req.GroupOrdering,
+		Seed:            seed,
 	}
 
-	if err := h.db.Create(&event).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create event"})
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if event.IsFinals {
+			var finalsEvents int64
+			if err := tx.Model(&models.Event{}).Where("season_id = ? AND is_finals = ?", season.ID, true).Count(&finalsEvents).Error; err != nil {
+				return err
+			}
+			if finalsEvents > 0 {
+				return NewAPIError(http.StatusConflict, CodeFinalsEventExists, "This season already has a finals event")
+			}
+		}
+		return tx.Create(&event).Error
+	})
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			c.Error(apiErr)
+			return
+		}
+		log.Printf("CreateEvent error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create event"))
 		return
 	}
 
@@ -95,91 +118,432 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 // ListEvents handles listing all events for a season
 // @Summary List events for a season
-// @Description Get a list of all events for a specific season
+// @Description Get a page of the events in a specific season, optionally filtered by date range, completion or whether they are finals
 // @Tags events
 // @Produce json
 // @Param leagueID path string true "League ID"
 // @Param seasonID path string true "Season ID"
-// @Success 200 {object} ListResponse{data=[]EventResponse} "List of events"
-// @Failure 400 {object} ErrorResponse "Invalid league ID or season ID"
+// @Param from query string false "Only events on or after this RFC3339 timestamp"
+// @Param to query string false "Only events on or before this RFC3339 timestamp"
+// @Param isComplete query bool false "Only completed, or uncompleted, events"
+// @Param isFinals query bool false "Only finals, or regular, events"
+// @Param sort query string false "Comma separated sort fields, each optionally prefixed with - for descending: date, name"
+// @Param page query int false "Page number, from 1" default(1)
+// @Param limit query int false "Events per page, at most 100" default(50)
+// @Success 200 {object} PagedResponse{data=[]EventResponse} "Page of events"
+// @Failure 400 {object} ErrorResponse "Invalid league ID, season ID, filter, sort or page"
+// @Failure 404 {object} ErrorResponse "League or season not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /leagues/{leagueID}/seasons/{seasonID}/events [get]
 func (h *EventHandler) ListEvents(c *gin.Context) {
-	leagueID := c.Param("leagueID")
-	seasonID := c.Param("seasonID")
+	season := c.MustGet("season").(*models.Season)
 
-	// Convert IDs to uint
-	_, err := strconv.ParseUint(leagueID, 10, 32)
+	var events []models.Event
+	pagination, err := newListQuery(c, h.db.Where("season_id = ?", season.ID), eventSortFields).
+		TimeRange("from", "to", "date").
+		Bool("isComplete", "is_complete").
+		Bool("isFinals", "is_finals").
+		Find(&events)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid league ID"})
+		respondWithError(c, "ListEvents", "Failed to fetch events", err)
 		return
 	}
 
-	seasonIDUint, err := strconv.ParseUint(seasonID, 10, 32)
+	c.JSON(http.StatusOK, gin.H{
+		"data":       events,
+		"pagination": pagination,
+	})
+}
+
+var eventSortFields = map[string]string{
+	"date": "date",
+	"name": "name",
+}
+
+// GetEvent handles getting a single event by ID
+// @Summary Get event by ID
+// @Description Get detailed information about a specific event, including the points each player has earned so far
+// @Tags events
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=EventDetailResponse} "Event details"
+// @Failure 400 {object} ErrorResponse "Invalid league ID, season ID, or event ID"
+// @Failure 404 {object} ErrorResponse "League, season or event not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID} [get]
+func (h *EventHandler) GetEvent(c *gin.Context) {
+	event := *c.MustGet("event").(*models.Event)
+
+	if err := h.db.Where("event_id = ?", event.ID).Find(&event.Reopenings).Error; err != nil {
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch event"))
+		return
+	}
+
+	points, err := h.scoringService.EventPoints(&event)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid season ID"})
+		log.Printf("GetEvent error - Failed to compute points: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to compute event points"))
 		return
 	}
 
-	var events []models.Event
-	if err := h.db.Where("season_id = ?", seasonIDUint).Find(&events).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch events"})
+	c.JSON(http.StatusOK, gin.H{
+		"data": EventDetailResponse{
+			Event:  event,
+			Points: points,
+		},
+	})
+}
+
+// GetSeeding handles getting the seeding order for an event
+// @Summary Get event seeding
+// @Description Get the players checked in to an event in seeding order. The order is derived from the event's seeding method and stored seed, so it can be reproduced.
+// @Tags events
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=SeedingResponse} "Event seeding"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/seeding [get]
+func (h *EventHandler) GetSeeding(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+
+	seeding, err := h.seedingService.SeedEvent(event)
+	if err != nil {
+		log.Printf("GetSeeding error - Failed to seed event %d: %v", event.ID, err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to seed event"))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": events,
+		"data": seeding,
 	})
 }
 
-// GetEvent handles getting a single event by ID
-// @Summary Get event by ID
-// @Description Get detailed information about a specific event
+// CompleteEvent handles completing an event and locking its results
+// @Summary Complete an event
+// @Description Check that every scheduled game has a result, store each player's final points, mark the event complete and update the season standings
 // @Tags events
 // @Produce json
+// @Security Bearer
 // @Param leagueID path string true "League ID"
 // @Param seasonID path string true "Season ID"
 // @Param eventID path string true "Event ID"
-// @Success 200 {object} ListResponse{data=EventResponse} "Event details"
-// @Failure 400 {object} ErrorResponse "Invalid league ID, season ID, or event ID"
+// @Success 200 {object} ListResponse{data=EventDetailResponse} "Event completed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
 // @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event already complete or results missing"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID} [get]
-func (h *EventHandler) GetEvent(c *gin.Context) {
-	leagueID := c.Param("leagueID")
-	seasonID := c.Param("seasonID")
-	eventID := c.Param("eventID")
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/complete [post]
+func (h *EventHandler) CompleteEvent(c *gin.Context) {
+	event := *c.MustGet("event").(*models.Event)
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		// Claiming the event first stops overlapping requests from completing it twice
+		event.IsComplete = true
+		event.CompletedAt = time.Now()
+		claim := tx.Model(&models.Event{}).Where("id = ? AND is_complete = ?", event.ID, false).
+			Updates(map[string]interface{}{"is_complete": true, "completed_at": event.CompletedAt})
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return NewAPIError(http.StatusConflict, CodeEventComplete, "Event is already complete")
+		}
+
+		scores, err := finalEventScores(tx, &event)
+		if err != nil {
+			return err
+		}
 
-	// Convert IDs to uint
-	_, err := strconv.ParseUint(leagueID, 10, 32)
+		if err := tx.Where("event_id = ?", event.ID).Delete(&models.EventScore{}).Error; err != nil {
+			return err
+		}
+		if len(scores) > 0 {
+			if err := tx.Create(&scores).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Season{}).Where("id = ?", event.SeasonID).
+			Update("event_count", gorm.Expr("event_count + 1")).Error
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid league ID"})
+		h.respondWithTransactionError(c, "CompleteEvent", err)
 		return
 	}
 
-	seasonIDUint, err := strconv.ParseUint(seasonID, 10, 32)
+	points, err := h.scoringService.EventPoints(&event)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid season ID"})
+		log.Printf("CompleteEvent error - Failed to load points: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to load event points"))
+		return
+	}
+
+	log.Printf("CompleteEvent success - Event %d completed with %d players scored", event.ID, len(points))
+	c.JSON(http.StatusOK, gin.H{
+		"data": EventDetailResponse{
+			Event:  event,
+			Points: points,
+		},
+	})
+}
+
+// ReopenEvent handles reopening a completed event for corrections
+// @Summary Reopen an event
+// @Description Reopen a completed event so its results can be corrected. Only the league owner may reopen an event, and the reason is recorded.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.ReopenEventRequest true "Reason for reopening"
+// @Success 200 {object} ListResponse{data=EventResponse} "Event reopened successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or event ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not the league owner"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event is not complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/reopen [post]
+func (h *EventHandler) ReopenEvent(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeUnauthorized, "Unauthorized"))
+		return
+	}
+
+	var req models.ReopenEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("ReopenEvent error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
 		return
 	}
 
-	eventIDUint, err := strconv.ParseUint(eventID, 10, 32)
+	event := *c.MustGet("event").(*models.Event)
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		event.IsComplete = false
+		event.CompletedAt = time.Time{}
+		claim := tx.Model(&models.Event{}).Where("id = ? AND is_complete = ?", event.ID, true).
+			Updates(map[string]interface{}{"is_complete": false, "completed_at": event.CompletedAt})
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return NewAPIError(http.StatusConflict, CodeConflict, "Event is not complete")
+		}
+		if err := tx.Model(&models.Season{}).Where("id = ? AND event_count > 0", event.SeasonID).
+			Update("event_count", gorm.Expr("event_count - 1")).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", event.ID).Delete(&models.EventScore{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.EventReopening{
+			EventID:    event.ID,
+			UserID:     userID.(uint),
+			Reason:     req.Reason,
+			ReopenedAt: time.Now(),
+		}).Error
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid event ID"})
+		h.respondWithTransactionError(c, "ReopenEvent", err)
 		return
 	}
 
-	var event models.Event
-	if err := h.db.Where("id = ? AND season_id = ?", eventIDUint, seasonIDUint).First(&event).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Event not found"})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch event"})
+	if err := h.db.Preload("Reopenings").First(&event, event.ID).Error; err != nil {
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch event"))
+		return
+	}
+
+	log.Printf("ReopenEvent success - Event %d reopened by user %d: %s", event.ID, userID, req.Reason)
+	c.JSON(http.StatusOK, gin.H{
+		"data": event,
+	})
+}
+
+// UpdateEvent handles changing an event's details
+// @Summary Update an event
+// @Description Change an event's name, date, winners group, seeding method or group ordering. Omitted fields are left unchanged. The date must fall within the season's start and end dates. Completed events must be reopened before they can be changed.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.UpdateEventRequest true "Event changes"
+// @Success 200 {object} ListResponse{data=EventResponse} "Event updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or event ID, or date outside the season"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event is complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID} [patch]
+func (h *EventHandler) UpdateEvent(c *gin.Context) {
+	var req models.UpdateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("UpdateEvent error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Date != nil {
+		// The rfc3339 binding rule has already checked the format
+		date, _ := time.Parse(time.RFC3339, *req.Date)
+		if !c.MustGet("season").(*models.Season).Contains(date) {
+			c.Error(invalidField("date", "season_window", "date must fall within the season's start and end dates"))
+			return
+		}
+		updates["date"] = date
+	}
+	if req.HasWinnersGroup != nil {
+		updates["has_winners_group"] = *req.HasWinnersGroup
+	}
+	if req.SeedingMethod != nil {
+		updates["seeding_method"] = *req.SeedingMethod
+	}
+	if req.GroupOrdering != nil {
+		updates["group_ordering"] = *req.GroupOrdering
+	}
+
+	event := *c.MustGet("event").(*models.Event)
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if event.IsComplete {
+			return NewAPIError(http.StatusConflict, CodeConflict, "Completed events must be reopened before they can be changed")
 		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&event).Updates(updates).Error
+	})
+	if err != nil {
+		h.respondWithTransactionError(c, "UpdateEvent", err)
 		return
 	}
 
+	log.Printf("UpdateEvent success - Event %d updated", event.ID)
 	c.JSON(http.StatusOK, gin.H{
 		"data": event,
 	})
 }
+
+// DeleteEvent handles deleting an event
+// @Summary Delete an event
+// @Description Delete an event along with its groups and schedule. Events with recorded games, finals or completed results cannot be deleted.
+// @Tags events
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 204 "Event deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event has recorded results"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID} [delete]
+func (h *EventHandler) DeleteEvent(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		return deleteEvents(tx, []uint{event.ID})
+	})
+	if err != nil {
+		respondWithError(c, "DeleteEvent", "Failed to delete", err)
+		return
+	}
+
+	log.Printf("DeleteEvent success - Event %d deleted", event.ID)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *EventHandler) respondWithTransactionError(c *gin.Context, action string, err error) {
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		c.Error(apiErr)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.Error(NewAPIError(http.StatusNotFound, CodeEventNotFound, "Event not found"))
+	default:
+		log.Printf("%s error - Database error: %v", action, err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to update event"))
+	}
+}
+
+// finalEventScores checks that an event has all of its results and returns
+// the scores to store for it. Regular events are scored from their games;
+// finals events store each player's place in the finals ranking.
+func finalEventScores(tx *gorm.DB, event *models.Event) ([]models.EventScore, error) {
+	if event.IsFinals {
+		var finals models.Finals
+		if err := tx.Where("event_id = ?", event.ID).Preload("Matches.Players.Player").First(&finals).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, NewAPIError(http.StatusConflict, CodeFinalsNotStarted, "Finals have not started")
+			}
+			return nil, err
+		}
+		if !finals.IsComplete {
+			return nil, NewAPIError(http.StatusConflict, CodeConflict, "Finals are not complete")
+		}
+
+		var scores []models.EventScore
+		for _, row := range services.ComputeFinalsRanking(finals.Matches) {
+			scores = append(scores, models.EventScore{
+				EventID:     event.ID,
+				PlayerID:    row.PlayerID,
+				Place:       row.Rank,
+				GamesPlayed: row.MatchesPlayed,
+			})
+		}
+		return scores, nil
+	}
+
+	var unplayed int64
+	if err := tx.Model(&models.ScheduledGame{}).Where("event_id = ? AND game_id IS NULL", event.ID).Count(&unplayed).Error; err != nil {
+		return nil, err
+	}
+	if unplayed > 0 {
+		return nil, NewAPIError(http.StatusConflict, CodeConflict, fmt.Sprintf("%d scheduled games have no results", unplayed))
+	}
+
+	var played int64
+	if err := tx.Model(&models.Game{}).Where("event_id = ?", event.ID).Count(&played).Error; err != nil {
+		return nil, err
+	}
+	if played == 0 {
+		return nil, NewAPIError(http.StatusConflict, CodeConflict, "No games have been recorded")
+	}
+
+	points, err := services.NewScoringService(tx).CalculateEventPoints(event)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]models.EventScore, len(points))
+	for i, p := range points {
+		scores[i] = models.EventScore{
+			EventID:     event.ID,
+			PlayerID:    p.PlayerID,
+			Points:      p.Points,
+			Place:       p.Place,
+			GamesPlayed: p.GamesPlayed,
+		}
+	}
+	return scores, nil
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/models"
+	"backend/services"
+)
+
+// newTestDB opens a database in the test's temporary directory with the given models migrated
+func newTestDB(t *testing.T, tables ...interface{}) *gorm.DB {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(tables...); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+func TestGetSeedingIFPARankLeavesUnsyncedPlayersUnranked(t *testing.T) {
+	db := newTestDB(t, &models.League{}, &models.Season{}, &models.Player{}, &models.Event{})
+
+	league := models.League{Name: "Tuesday League", Location: "Arcade", DateCreated: time.Now(), OwnerID: 1}
+	db.Create(&league)
+	season := models.Season{Name: "Spring", DateCreated: time.Now(), LeagueID: league.ID}
+	db.Create(&season)
+	fetchedAt := time.Now()
+	event := models.Event{
+		Name:          "Week 1",
+		Date:          time.Now(),
+		SeasonID:      season.ID,
+		SeedingMethod: models.SeedingMethodIFPARank,
+		Players: []models.Player{
+			{Name: "Keith", LeagueID: league.ID, IFPANumber: "1234"},
+			{Name: "Zach", LeagueID: league.ID, IFPANumber: "5678", IFPARank: 12, IFPAFetchedAt: &fetchedAt},
+		},
+	}
+	if err := db.Create(&event).Error; err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+
+	scoringService := services.NewScoringService(db)
+	handler := NewEventHandler(db, scoringService, services.NewSeedingService(db, scoringService))
+
+	router := gin.New()
+	router.Use(ErrorHandler)
+	router.GET("/events/:eventID/seeding", NewResourceLoader(db).LoadPathResources, handler.GetSeeding)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events/1/seeding", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", recorder.Code, recorder.Body)
+	}
+	var response struct {
+		Data services.Seeding `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	players := response.Data.Players
+	if len(players) != 2 {
+		t.Fatalf("got %d seeded players, want 2", len(players))
+	}
+	if players[0].Player.Name != "Zach" || !players[0].Ranked || players[0].Value != 12 {
+		t.Errorf("first seed = %+v, want the synced player ranked 12", players[0])
+	}
+	if players[1].Player.Name != "Keith" || players[1].Ranked {
+		t.Errorf("second seed = %+v, want the unsynced player unranked", players[1])
+	}
+}
+
+// A request that loaded the event before another request completed it must
+// not complete it a second time
+func TestCompleteEventAlreadyCompletedConcurrently(t *testing.T) {
+	db := newTestDB(t, &models.League{}, &models.Season{}, &models.Event{}, &models.EventScore{})
+
+	league := models.League{Name: "Tuesday League", Location: "Arcade", DateCreated: time.Now(), OwnerID: 1}
+	db.Create(&league)
+	season := models.Season{Name: "Spring", DateCreated: time.Now(), LeagueID: league.ID, EventCount: 1}
+	db.Create(&season)
+	event := models.Event{Name: "Week 1", Date: time.Now(), SeasonID: season.ID}
+	db.Create(&event)
+	stale := event
+	db.Model(&event).Update("is_complete", true)
+
+	scoringService := services.NewScoringService(db)
+	handler := NewEventHandler(db, scoringService, services.NewSeedingService(db, scoringService))
+
+	router := gin.New()
+	router.Use(ErrorHandler)
+	router.POST("/events/:eventID/complete", func(c *gin.Context) { c.Set("event", &stale) }, handler.CompleteEvent)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/events/1/complete", nil))
+
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409: %s", recorder.Code, recorder.Body)
+	}
+	db.First(&season, season.ID)
+	if season.EventCount != 1 {
+		t.Errorf("season event count = %d, want 1", season.EventCount)
+	}
+}
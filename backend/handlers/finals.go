@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+	"backend/services"
+)
+
+type FinalsHandler struct {
+	db             *gorm.DB
+	scoringService *services.ScoringService
+}
+
+func NewFinalsHandler(db *gorm.DB, scoringService *services.ScoringService) *FinalsHandler {
+	return &FinalsHandler{
+		db:             db,
+		scoringService: scoringService,
+	}
+}
+
+// StartFinals handles qualifying players and building the finals bracket
+// @Summary Start the finals
+// @Description Qualify the top players from the season standings and build the first round of the finals bracket. Only allowed once every regular season event is complete.
+// @Tags finals
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.StartFinalsRequest true "Finals format and number of qualifiers"
+// @Success 201 {object} ListResponse{data=FinalsResponse} "Finals started successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body, event ID, or qualifier count"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Finals already started or regular season not complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/finals [post]
+func (h *FinalsHandler) StartFinals(c *gin.Context) {
+	var req models.StartFinalsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("StartFinals error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	season := c.MustGet("season").(*models.Season)
+	event := c.MustGet("event").(*models.Event)
+	if !event.IsFinals || !season.HasFinals {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, "Event is not a finals event"))
+		return
+	}
+	if event.IsComplete {
+		c.Error(NewAPIError(http.StatusConflict, CodeEventComplete, "Event is already complete"))
+		return
+	}
+
+	var existing int64
+	if err := h.db.Model(&models.Finals{}).Where("event_id = ?", event.ID).Count(&existing).Error; err != nil {
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch finals"))
+		return
+	}
+	if existing > 0 {
+		c.Error(NewAPIError(http.StatusConflict, CodeFinalsStarted, "Finals have already started"))
+		return
+	}
+
+	var regularEvents, incompleteEvents int64
+	if err := h.db.Model(&models.Event{}).Where("season_id = ? AND is_finals = ?", event.SeasonID, false).Count(&regularEvents).Error; err != nil {
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch events"))
+		return
+	}
+	if err := h.db.Model(&models.Event{}).Where("season_id = ? AND is_finals = ? AND is_complete = ?", event.SeasonID, false, false).Count(&incompleteEvents).Error; err != nil {
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch events"))
+		return
+	}
+	if regularEvents == 0 || incompleteEvents > 0 {
+		c.Error(NewAPIError(http.StatusConflict, CodeConflict, "Regular season events must be complete before finals can start"))
+		return
+	}
+
+	standings, err := h.scoringService.SeasonStandings(event.SeasonID)
+	if err != nil {
+		log.Printf("StartFinals error - Failed to compute standings: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to compute standings"))
+		return
+	}
+	if len(standings) < req.Qualifiers {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("Only %d players are in the standings", len(standings))))
+		return
+	}
+
+	qualifierIDs := make([]uint, req.Qualifiers)
+	for i := range qualifierIDs {
+		qualifierIDs[i] = standings[i].PlayerID
+	}
+
+	matches, err := services.BuildFirstRound(req.Format, qualifierIDs)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, err.Error()))
+		return
+	}
+
+	finals := models.Finals{
+		EventID:        event.ID,
+		Format:         req.Format,
+		QualifierCount: req.Qualifiers,
+		CurrentRound:   1,
+		Matches:        matches,
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&finals).Error; err != nil {
+			return err
+		}
+		var qualifiers []models.Player
+		if err := tx.Find(&qualifiers, qualifierIDs).Error; err != nil {
+			return err
+		}
+		return tx.Model(event).Omit("Players.*").Association("Players").Append(&qualifiers)
+	})
+	if err != nil {
+		log.Printf("StartFinals error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to start finals"))
+		return
+	}
+
+	log.Printf("StartFinals success - Started %s finals with %d qualifiers for event %d", finals.Format, finals.QualifierCount, event.ID)
+	h.respondWithFinals(c, http.StatusCreated, event.ID)
+}
+
+// GetFinals handles getting the finals bracket for an event
+// @Summary Get the finals
+// @Description Get the finals bracket for an event with every round played so far and the current ranking
+// @Tags finals
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=FinalsResponse} "Finals bracket"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 404 {object} ErrorResponse "Event not found or finals not started"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/finals [get]
+func (h *FinalsHandler) GetFinals(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+	h.respondWithFinals(c, http.StatusOK, event.ID)
+}
+
+// RecordMatchResult handles recording the finishing positions of a finals match
+// @Summary Record a finals match result
+// @Description Record where each player finished in a finals match. When every match in the round is complete the next round is built, or the finals are completed after the last match.
+// @Tags finals
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param matchID path string true "Match ID"
+// @Param request body models.FinalsMatchResultRequest true "Finishing positions"
+// @Success 200 {object} ListResponse{data=FinalsResponse} "Result recorded successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body, event ID, or match ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event, finals or match not found"
+// @Failure 409 {object} ErrorResponse "Match already played or not in the current round"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/finals/matches/{matchID}/results [post]
+func (h *FinalsHandler) RecordMatchResult(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+
+	matchID, err := strconv.ParseUint(c.Param("matchID"), 10, 32)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeInvalidID, "Invalid match ID"))
+		return
+	}
+
+	var req models.FinalsMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("RecordMatchResult error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	var finals models.Finals
+	if err := h.db.Where("event_id = ?", event.ID).First(&finals).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Error(NewAPIError(http.StatusNotFound, CodeFinalsNotStarted, "Finals have not started"))
+			return
+		}
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch finals"))
+		return
+	}
+
+	var match models.FinalsMatch
+	if err := h.db.Preload("Players").Where("id = ? AND finals_id = ?", matchID, finals.ID).First(&match).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Error(NewAPIError(http.StatusNotFound, CodeMatchNotFound, "Match not found"))
+			return
+		}
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch match"))
+		return
+	}
+
+	if finals.IsComplete || match.IsComplete {
+		c.Error(NewAPIError(http.StatusConflict, CodeConflict, "Match has already been played"))
+		return
+	}
+	if match.Round != finals.CurrentRound {
+		c.Error(NewAPIError(http.StatusConflict, CodeConflict, fmt.Sprintf("Match is not in the current round (%d)", finals.CurrentRound)))
+		return
+	}
+
+	positions, err := validateMatchResult(&match, &req)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeValidationFailed, err.Error()))
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		for _, player := range match.Players {
+			if err := tx.Model(&player).Update("position", positions[player.PlayerID]).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&match).Update("is_complete", true).Error; err != nil {
+			return err
+		}
+
+		var roundMatches []models.FinalsMatch
+		if err := tx.Preload("Players").Where("finals_id = ? AND round = ?", finals.ID, finals.CurrentRound).Find(&roundMatches).Error; err != nil {
+			return err
+		}
+		for _, roundMatch := range roundMatches {
+			if !roundMatch.IsComplete {
+				return nil
+			}
+		}
+
+		next := services.NextRound(finals.Format, roundMatches)
+		if next == nil {
+			return tx.Model(&finals).Update("is_complete", true).Error
+		}
+		for i := range next {
+			next[i].FinalsID = finals.ID
+		}
+		if err := tx.Create(&next).Error; err != nil {
+			return err
+		}
+		return tx.Model(&finals).Update("current_round", finals.CurrentRound+1).Error
+	})
+	if err != nil {
+		log.Printf("RecordMatchResult error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to record match result"))
+		return
+	}
+
+	log.Printf("RecordMatchResult success - Recorded finals match %d for event %d", match.ID, event.ID)
+	h.respondWithFinals(c, http.StatusOK, event.ID)
+}
+
+func (h *FinalsHandler) respondWithFinals(c *gin.Context, status int, eventID uint) {
+	var finals models.Finals
+	if err := h.db.Where("event_id = ?", eventID).
+		Preload("Matches", func(db *gorm.DB) *gorm.DB { return db.Order("round, number") }).
+		Preload("Matches.Players", func(db *gorm.DB) *gorm.DB { return db.Order("seed") }).
+		Preload("Matches.Players.Player").
+		First(&finals).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Error(NewAPIError(http.StatusNotFound, CodeFinalsNotStarted, "Finals have not started"))
+			return
+		}
+		log.Printf("GetFinals error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch finals"))
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"data": FinalsResponse{
+			Finals:  finals,
+			Ranking: services.ComputeFinalsRanking(finals.Matches),
+		},
+	})
+}
+
+// validateMatchResult checks that every player in the match has exactly one
+// finishing position, returning the positions keyed by player ID
+func validateMatchResult(match *models.FinalsMatch, req *models.FinalsMatchResultRequest) (map[uint]int, error) {
+	if len(req.Results) != len(match.Players) {
+		return nil, fmt.Errorf("Expected results for %d players", len(match.Players))
+	}
+
+	inMatch := make(map[uint]bool, len(match.Players))
+	for _, player := range match.Players {
+		inMatch[player.PlayerID] = true
+	}
+
+	positions := make(map[uint]int, len(req.Results))
+	seenPositions := make(map[int]bool, len(req.Results))
+	for _, result := range req.Results {
+		if !inMatch[result.PlayerID] {
+			return nil, fmt.Errorf("Player %d is not in this match", result.PlayerID)
+		}
+		if _, ok := positions[result.PlayerID]; ok {
+			return nil, fmt.Errorf("Player %d appears more than once", result.PlayerID)
+		}
+		if result.Position > len(match.Players) {
+			return nil, fmt.Errorf("Position %d is out of range for a %d player match", result.Position, len(match.Players))
+		}
+		if seenPositions[result.Position] {
+			return nil, fmt.Errorf("Position %d is assigned more than once", result.Position)
+		}
+		positions[result.PlayerID] = result.Position
+		seenPositions[result.Position] = true
+	}
+
+	return positions, nil
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+type GameHandler struct {
+	db *gorm.DB
+}
+
+func NewGameHandler(db *gorm.DB) *GameHandler {
+	return &GameHandler{db: db}
+}
+
+// CreateGame handles recording the result of a game played during an event
+// @Summary Record a game result
+// @Description Record the finishing position (and optionally the score) of each player in a group on a machine
+// @Tags games
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.CreateGameRequest true "Game details"
+// @Success 201 {object} ListResponse{data=GameResponse} "Game recorded successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or event ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event is already complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/games [post]
+func (h *GameHandler) CreateGame(c *gin.Context) {
+	var req models.CreateGameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("CreateGame error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	event := *c.MustGet("event").(*models.Event)
+	if event.IsComplete {
+		c.Error(NewAPIError(http.StatusConflict, CodeEventComplete, "Event is already complete"))
+		return
+	}
+
+	// The results are checked against the event's players and machines
+	if err := h.db.Model(&event).Association("Players").Find(&event.Players); err != nil {
+		log.Printf("CreateGame error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch event players"))
+		return
+	}
+	if err := h.db.Model(&event).Association("Machines").Find(&event.Machines); err != nil {
+		log.Printf("CreateGame error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch event machines"))
+		return
+	}
+
+	if err := validateGameRequest(&event, &req); err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeValidationFailed, err.Error()))
+		return
+	}
+
+	if req.Round > 0 && req.GroupID == nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, "A group is required when recording a scheduled round"))
+		return
+	}
+
+	if req.GroupID != nil {
+		var group models.Group
+		if err := h.db.Preload("Players").Where("id = ? AND event_id = ?", *req.GroupID, event.ID).First(&group).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("Group %d is not part of this event", *req.GroupID)))
+				return
+			}
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch group"))
+			return
+		}
+		if err := validateGameGroup(&group, &req); err != nil {
+			c.Error(NewAPIError(http.StatusBadRequest, CodeValidationFailed, err.Error()))
+			return
+		}
+	}
+
+	var scheduled *models.ScheduledGame
+	if req.Round > 0 {
+		var slot models.ScheduledGame
+		if err := h.db.Where("event_id = ? AND group_id = ? AND round = ?", event.ID, *req.GroupID, req.Round).First(&slot).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("Group %d has no game scheduled in round %d", *req.GroupID, req.Round)))
+				return
+			}
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch schedule"))
+			return
+		}
+		if slot.MachineID != req.MachineID {
+			c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("Round %d is scheduled on machine %d", req.Round, slot.MachineID)))
+			return
+		}
+		if slot.GameID != nil {
+			c.Error(NewAPIError(http.StatusConflict, CodeConflict, fmt.Sprintf("Round %d has already been recorded for this group", req.Round)))
+			return
+		}
+		scheduled = &slot
+	}
+
+	game := models.Game{
+		EventID:   event.ID,
+		GroupID:   req.GroupID,
+		Round:     req.Round,
+		MachineID: req.MachineID,
+	}
+	for _, result := range req.Results {
+		game.Results = append(game.Results, models.GameResult{
+			PlayerID: result.PlayerID,
+			Position: result.Position,
+			Score:    result.Score,
+		})
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&game).Error; err != nil {
+			return err
+		}
+		if scheduled != nil {
+			return tx.Model(scheduled).Update("game_id", game.ID).Error
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("CreateGame error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to record game"))
+		return
+	}
+
+	if err := h.db.Preload("Machine").Preload("Results.Player").First(&game, game.ID).Error; err != nil {
+		log.Printf("CreateGame error - Failed to load game: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to record game"))
+		return
+	}
+
+	log.Printf("CreateGame success - Game %d recorded for event %d", game.ID, event.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"data": game,
+	})
+}
+
+// ListGames handles listing all recorded games for an event
+// @Summary List games for an event
+// @Description Get all recorded games and their results for a specific event
+// @Tags games
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=[]GameResponse} "List of games"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/games [get]
+func (h *GameHandler) ListGames(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+
+	var games []models.Game
+	if err := h.db.Where("event_id = ?", event.ID).
+		Preload("Machine").
+		Preload("Results", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Results.Player").
+		Order("id").
+		Find(&games).Error; err != nil {
+		log.Printf("ListGames error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch games"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": games,
+	})
+}
+
+// DeleteGame handles removing an incorrectly recorded game
+// @Summary Delete a recorded game
+// @Description Remove a game and its results from an event that is not yet complete
+// @Tags games
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param gameID path string true "Game ID"
+// @Success 204 "Game deleted"
+// @Failure 400 {object} ErrorResponse "Invalid event ID or game ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Game not found"
+// @Failure 409 {object} ErrorResponse "Event is already complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/games/{gameID} [delete]
+func (h *GameHandler) DeleteGame(c *gin.Context) {
+	gameID, err := strconv.ParseUint(c.Param("gameID"), 10, 32)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeInvalidID, "Invalid game ID"))
+		return
+	}
+
+	event := c.MustGet("event").(*models.Event)
+	if event.IsComplete {
+		c.Error(NewAPIError(http.StatusConflict, CodeEventComplete, "Event is already complete"))
+		return
+	}
+
+	var game models.Game
+	if err := h.db.Where("id = ? AND event_id = ?", gameID, event.ID).First(&game).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Error(NewAPIError(http.StatusNotFound, CodeGameNotFound, "Game not found"))
+			return
+		}
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch game"))
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ScheduledGame{}).Where("game_id = ?", game.ID).Update("game_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("game_id = ?", game.ID).Delete(&models.GameResult{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&game).Error
+	})
+	if err != nil {
+		log.Printf("DeleteGame error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to delete game"))
+		return
+	}
+
+	log.Printf("DeleteGame success - Game %d deleted from event %d", game.ID, event.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// validateGameRequest checks a game against the event's checked-in players and machines
+func validateGameRequest(event *models.Event, req *models.CreateGameRequest) error {
+	machineInEvent := false
+	for _, machine := range event.Machines {
+		if machine.ID == req.MachineID {
+			machineInEvent = true
+			break
+		}
+	}
+	if !machineInEvent {
+		return fmt.Errorf("Machine %d is not part of this event", req.MachineID)
+	}
+
+	eventPlayers := make(map[uint]bool, len(event.Players))
+	for _, player := range event.Players {
+		eventPlayers[player.ID] = true
+	}
+
+	seenPlayers := make(map[uint]bool, len(req.Results))
+	seenPositions := make(map[int]bool, len(req.Results))
+	for _, result := range req.Results {
+		if !eventPlayers[result.PlayerID] {
+			return fmt.Errorf("Player %d is not playing in this event", result.PlayerID)
+		}
+		if seenPlayers[result.PlayerID] {
+			return fmt.Errorf("Player %d appears more than once", result.PlayerID)
+		}
+		if result.Position > len(req.Results) {
+			return fmt.Errorf("Position %d is out of range for a %d player game", result.Position, len(req.Results))
+		}
+		if seenPositions[result.Position] {
+			return fmt.Errorf("Position %d is assigned more than once", result.Position)
+		}
+		seenPlayers[result.PlayerID] = true
+		seenPositions[result.Position] = true
+	}
+
+	return nil
+}
+
+// validateGameGroup checks that a game has exactly one result for every player in the group it
+// was played by
+func validateGameGroup(group *models.Group, req *models.CreateGameRequest) error {
+	if len(req.Results) != len(group.Players) {
+		return fmt.Errorf("Group %d has %d players but the game has %d results", group.Number, len(group.Players), len(req.Results))
+	}
+
+	groupPlayers := make(map[uint]bool, len(group.Players))
+	for _, player := range group.Players {
+		groupPlayers[player.ID] = true
+	}
+
+	for _, result := range req.Results {
+		if !groupPlayers[result.PlayerID] {
+			return fmt.Errorf("Player %d is not in group %d", result.PlayerID, group.Number)
+		}
+	}
+
+	return nil
+}
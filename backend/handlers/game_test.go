@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+func TestValidateGameGroup(t *testing.T) {
+	group := &models.Group{Number: 1, Players: []models.Player{
+		{Model: gorm.Model{ID: 1}},
+		{Model: gorm.Model{ID: 2}},
+		{Model: gorm.Model{ID: 3}},
+		{Model: gorm.Model{ID: 4}},
+	}}
+
+	tests := []struct {
+		name      string
+		playerIDs []uint
+		wantErr   bool
+	}{
+		{"whole group", []uint{1, 2, 3, 4}, false},
+		{"missing players", []uint{1, 2}, true},
+		{"single result", []uint{1}, true},
+		{"player from another group", []uint{1, 2, 3, 5}, true},
+		{"extra player", []uint{1, 2, 3, 4, 5}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &models.CreateGameRequest{MachineID: 1}
+			for i, id := range tt.playerIDs {
+				req.Results = append(req.Results, models.GameResultRequest{PlayerID: id, Position: i + 1})
+			}
+			if err := validateGameGroup(group, req); (err != nil) != tt.wantErr {
+				t.Errorf("validateGameGroup error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+	"backend/services"
+)
+
+type GroupHandler struct {
+	db             *gorm.DB
+	seedingService *services.SeedingService
+}
+
+func NewGroupHandler(db *gorm.DB, seedingService *services.SeedingService) *GroupHandler {
+	return &GroupHandler{
+		db:             db,
+		seedingService: seedingService,
+	}
+}
+
+// GenerateGroups handles generating the groups for an event
+// @Summary Generate groups for an event
+// @Description Seed the event's players and split them into groups of four (with threes for the remainder), replacing any existing groups
+// @Tags groups
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 201 {object} ListResponse{data=[]GroupResponse} "Groups generated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid event ID or player count"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event has already started"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/groups/generate [post]
+func (h *GroupHandler) GenerateGroups(c *gin.Context) {
+	event, ok := loadEditableEvent(c, h.db)
+	if !ok {
+		return
+	}
+
+	seeding, err := h.seedingService.SeedEvent(event)
+	if err != nil {
+		log.Printf("GenerateGroups error - Failed to seed event %d: %v", event.ID, err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to seed event"))
+		return
+	}
+
+	groups, err := services.BuildGroups(seeding.Players, event.GroupOrdering, event.HasWinnersGroup, event.Seed)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, err.Error()))
+		return
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		return replaceGroups(tx, event.ID, groups)
+	}); err != nil {
+		log.Printf("GenerateGroups error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to save groups"))
+		return
+	}
+
+	log.Printf("GenerateGroups success - Generated %d groups for event %d", len(groups), event.ID)
+	h.respondWithGroups(c, http.StatusCreated, event.ID)
+}
+
+// ListGroups handles listing the groups for an event
+// @Summary List groups for an event
+// @Description Get the groups for a specific event and the players in each
+// @Tags groups
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=[]GroupResponse} "List of groups"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/groups [get]
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+	h.respondWithGroups(c, http.StatusOK, event.ID)
+}
+
+// UpdateGroups handles manually rearranging the groups for an event
+// @Summary Update groups for an event
+// @Description Replace the event's groups with the given arrangement. Every checked-in player must be in exactly one group of three or four, and at most one group can be the winners group. Only allowed before any game has been recorded.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.UpdateGroupsRequest true "Group arrangement"
+// @Success 200 {object} ListResponse{data=[]GroupResponse} "Groups updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body, event ID or group arrangement"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event has already started"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/groups [put]
+func (h *GroupHandler) UpdateGroups(c *gin.Context) {
+	var req models.UpdateGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("UpdateGroups error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	event, ok := loadEditableEvent(c, h.db)
+	if !ok {
+		return
+	}
+
+	var eventPlayers []models.Player
+	if err := h.db.Model(event).Association("Players").Find(&eventPlayers); err != nil {
+		log.Printf("UpdateGroups error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch event players"))
+		return
+	}
+	playersByID := make(map[uint]models.Player, len(eventPlayers))
+	for _, player := range eventPlayers {
+		playersByID[player.ID] = player
+	}
+
+	if details := checkGroupArrangement(req.Groups, eventPlayers); len(details) > 0 {
+		c.Error(&APIError{
+			Status:  http.StatusBadRequest,
+			Code:    CodeValidationFailed,
+			Message: "Invalid group arrangement",
+			Details: details,
+		})
+		return
+	}
+
+	groups := make([]models.Group, len(req.Groups))
+	for i, groupReq := range req.Groups {
+		groups[i] = models.Group{
+			Number:         i + 1,
+			IsWinnersGroup: groupReq.IsWinnersGroup,
+		}
+		for _, playerID := range groupReq.PlayerIDs {
+			groups[i].Players = append(groups[i].Players, playersByID[playerID])
+		}
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error {
+		return replaceGroups(tx, event.ID, groups)
+	}); err != nil {
+		log.Printf("UpdateGroups error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to save groups"))
+		return
+	}
+
+	log.Printf("UpdateGroups success - Updated %d groups for event %d", len(groups), event.ID)
+	h.respondWithGroups(c, http.StatusOK, event.ID)
+}
+
+// checkGroupArrangement checks a manual arrangement against the rules
+// GenerateGroups follows: every checked-in player is in exactly one group,
+// every group has three or four players, and at most one is the winners group
+func checkGroupArrangement(groups []models.GroupRequest, eventPlayers []models.Player) []FieldError {
+	checkedIn := make(map[uint]bool, len(eventPlayers))
+	for _, player := range eventPlayers {
+		checkedIn[player.ID] = true
+	}
+
+	var details []FieldError
+	assigned := make(map[uint]bool)
+	winnersGroups := 0
+	for i, group := range groups {
+		field := fmt.Sprintf("groups[%d]", i)
+		if len(group.PlayerIDs) < 3 || len(group.PlayerIDs) > 4 {
+			details = append(details, FieldError{
+				Field:   field + ".playerIDs",
+				Rule:    "group_size",
+				Message: field + ".playerIDs must have three or four players",
+			})
+		}
+		for _, playerID := range group.PlayerIDs {
+			switch {
+			case !checkedIn[playerID]:
+				details = append(details, FieldError{
+					Field:   field + ".playerIDs",
+					Rule:    "checked_in",
+					Message: fmt.Sprintf("Player %d is not playing in this event", playerID),
+				})
+			case assigned[playerID]:
+				details = append(details, FieldError{
+					Field:   field + ".playerIDs",
+					Rule:    "unique",
+					Message: fmt.Sprintf("Player %d is in more than one group", playerID),
+				})
+			}
+			assigned[playerID] = true
+		}
+		if group.IsWinnersGroup {
+			winnersGroups++
+			if winnersGroups > 1 {
+				details = append(details, FieldError{
+					Field:   field + ".isWinnersGroup",
+					Rule:    "single_winners_group",
+					Message: "Only one group can be the winners group",
+				})
+			}
+		}
+	}
+
+	for _, player := range eventPlayers {
+		if !assigned[player.ID] {
+			details = append(details, FieldError{
+				Field:   "groups",
+				Rule:    "all_players",
+				Message: fmt.Sprintf("Player %d is checked in but not in any group", player.ID),
+			})
+		}
+	}
+
+	return details
+}
+
+// loadEditableEvent returns the event from the path after checking that its
+// groups and schedule can still be changed, writing an error response if not
+func loadEditableEvent(c *gin.Context, db *gorm.DB) (*models.Event, bool) {
+	event := c.MustGet("event").(*models.Event)
+	if event.IsComplete {
+		c.Error(NewAPIError(http.StatusConflict, CodeEventComplete, "Event is already complete"))
+		return nil, false
+	}
+
+	var gameCount int64
+	if err := db.Model(&models.Game{}).Where("event_id = ?", event.ID).Count(&gameCount).Error; err != nil {
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch games"))
+		return nil, false
+	}
+	if gameCount > 0 {
+		c.Error(NewAPIError(http.StatusConflict, CodeConflict, "Groups and schedule cannot be changed once games have been recorded"))
+		return nil, false
+	}
+
+	return event, true
+}
+
+func (h *GroupHandler) respondWithGroups(c *gin.Context, status int, eventID uint) {
+	var groups []models.Group
+	if err := h.db.Where("event_id = ?", eventID).Preload("Players").Order("number").Find(&groups).Error; err != nil {
+		log.Printf("ListGroups error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch groups"))
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"data": groups,
+	})
+}
+
+// replaceGroups deletes an event's existing groups, along with the schedule
+// built from them, and saves the new ones
+func replaceGroups(tx *gorm.DB, eventID uint, groups []models.Group) error {
+	if err := tx.Where("event_id = ?", eventID).Delete(&models.ScheduledGame{}).Error; err != nil {
+		return err
+	}
+
+	var existing []models.Group
+	if err := tx.Where("event_id = ?", eventID).Find(&existing).Error; err != nil {
+		return err
+	}
+	for i := range existing {
+		if err := tx.Model(&existing[i]).Association("Players").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Delete(&existing[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	for i := range groups {
+		groups[i].EventID = eventID
+		if err := tx.Omit("Players.*").Create(&groups[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -8,34 +9,64 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/joho/godotenv"
+
+	"backend/services"
+)
+
+const (
+	// TokenIssuer is the iss claim of every access token
+	TokenIssuer = "pinball-league"
+	// TokenAudience is the aud claim every access token must carry
+	TokenAudience = "pinball-league-api"
 )
 
 var (
 	// JWTSecret is loaded from environment variables
 	JWTSecret string
-	// TokenExpiration is loaded from environment variables
+	// TokenExpiration is how long an access token lasts, loaded from
+	// environment variables
 	TokenExpiration time.Duration
+	// RefreshTokenExpiration is how long a refresh token lasts, loaded from
+	// environment variables
+	RefreshTokenExpiration time.Duration
 )
 
-func init() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		panic("Error loading .env file")
-	}
-
+// LoadJWTConfig reads the JWT secret and token lifetimes from environment
+// variables. It must be called before any token is issued or validated.
+func LoadJWTConfig() error {
 	// Load JWT secret
 	JWTSecret = os.Getenv("JWT_SECRET")
 	if JWTSecret == "" {
-		panic("JWT_SECRET environment variable is required")
+		return errors.New("JWT_SECRET environment variable is required")
 	}
 
-	// Load token expiration
-	expHours, err := strconv.Atoi(os.Getenv("JWT_EXPIRATION_HOURS"))
-	if err != nil {
-		panic("JWT_EXPIRATION_HOURS must be a valid integer")
+	// Access tokens are short-lived: JWT_EXPIRATION_MINUTES, or the older
+	// JWT_EXPIRATION_HOURS, defaulting to 15 minutes
+	TokenExpiration = 15 * time.Minute
+	if minutes := os.Getenv("JWT_EXPIRATION_MINUTES"); minutes != "" {
+		n, err := strconv.Atoi(minutes)
+		if err != nil || n <= 0 {
+			return errors.New("JWT_EXPIRATION_MINUTES must be a positive integer")
+		}
+		TokenExpiration = time.Duration(n) * time.Minute
+	} else if hours := os.Getenv("JWT_EXPIRATION_HOURS"); hours != "" {
+		n, err := strconv.Atoi(hours)
+		if err != nil || n <= 0 {
+			return errors.New("JWT_EXPIRATION_HOURS must be a positive integer")
+		}
+		TokenExpiration = time.Duration(n) * time.Hour
+	}
+
+	// Refresh tokens default to 30 days
+	RefreshTokenExpiration = 30 * 24 * time.Hour
+	if hours := os.Getenv("JWT_REFRESH_EXPIRATION_HOURS"); hours != "" {
+		n, err := strconv.Atoi(hours)
+		if err != nil || n <= 0 {
+			return errors.New("JWT_REFRESH_EXPIRATION_HOURS must be a positive integer")
+		}
+		RefreshTokenExpiration = time.Duration(n) * time.Hour
 	}
-	TokenExpiration = time.Duration(expHours) * time.Hour
+	return nil
 }
 
 // Claims represents the JWT claims
@@ -44,84 +75,54 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a new JWT token for a user
-func GenerateToken(userID uint) (string, error) {
-	// Get the JWT secret from environment variables
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		log.Printf("JWT_SECRET not set in environment variables")
-		return "", fmt.Errorf("JWT_SECRET not set")
+// GenerateToken creates a new access token for a user, valid for TokenExpiration
+func GenerateToken(userID uint) (string, *Claims, error) {
+	jti, err := services.NewTokenID()
+	if err != nil {
+		return "", nil, err
 	}
 
-	log.Printf("Generating token for user ID: %d", userID)
-
-	// Create the claims
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // Token expires in 24 hours
-		"iat":     time.Now().Unix(),
+	now := time.Now()
+	claims := &Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    TokenIssuer,
+			Audience:  jwt.ClaimStrings{TokenAudience},
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenExpiration)),
+		},
 	}
 
-	// Create token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
 	// Sign and get the complete encoded token as a string
-	tokenString, err := token.SignedString([]byte(secret))
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(JWTSecret))
 	if err != nil {
 		log.Printf("Error signing token: %v", err)
-		return "", err
+		return "", nil, err
 	}
 
-	log.Printf("Token generated successfully for user ID: %d", userID)
-	return tokenString, nil
+	return tokenString, claims, nil
 }
 
-// ValidateToken verifies a JWT token and returns the user ID
-func ValidateToken(tokenString string) (uint, error) {
-	// Get the JWT secret from environment variables
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		log.Printf("JWT_SECRET not set in environment variables")
-		return 0, fmt.Errorf("JWT_SECRET not set")
-	}
-
-	log.Printf("Validating token: %s", tokenString)
-
-	// Parse the token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validate the alg
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			log.Printf("Unexpected signing method: %v", token.Header["alg"])
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
-
+// ValidateToken verifies an access token's signature, expiry, issuer and
+// audience and returns its claims. It does not check for revocation.
+func ValidateToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(JWTSecret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(TokenIssuer),
+		jwt.WithAudience(TokenAudience),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
-		log.Printf("Error parsing token: %v", err)
-		return 0, err
+		return nil, err
 	}
-
-	if !token.Valid {
-		log.Printf("Token is invalid")
-		return 0, fmt.Errorf("invalid token")
-	}
-
-	// Get the claims
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		log.Printf("Invalid token claims")
-		return 0, fmt.Errorf("invalid token claims")
+	if !token.Valid || claims.ID == "" || claims.UserID == 0 {
+		return nil, fmt.Errorf("invalid token claims")
 	}
 
-	// Get the user ID
-	userID, ok := claims["user_id"].(float64)
-	if !ok {
-		log.Printf("Invalid user_id claim")
-		return 0, fmt.Errorf("invalid user_id claim")
-	}
-
-	log.Printf("Token validated successfully for user ID: %d", uint(userID))
-	return uint(userID), nil
+	return claims, nil
 }
-
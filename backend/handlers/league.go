@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,13 +16,15 @@ import (
 
 type LeagueHandler struct {
 	db          *gorm.DB
-	ifpaService *services.IFPAService
+	ifpaService services.PlayerRegistry
+	ifpaSync    *services.IFPASyncService
 }
 
-func NewLeagueHandler(db *gorm.DB, ifpaService *services.IFPAService) *LeagueHandler {
+func NewLeagueHandler(db *gorm.DB, ifpaService services.PlayerRegistry, ifpaSync *services.IFPASyncService) *LeagueHandler {
 	return &LeagueHandler{
 		db:          db,
 		ifpaService: ifpaService,
+		ifpaSync:    ifpaSync,
 	}
 }
 
@@ -37,12 +40,12 @@ func NewLeagueHandler(db *gorm.DB, ifpaService *services.IFPAService) *LeagueHan
 // @Failure 400 {object} ErrorResponse "Invalid request body"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /leagues/create [post]
+// @Router /leagues [post]
 func (h *LeagueHandler) CreateLeague(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
 		log.Printf("CreateLeague error - No user ID in context")
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeUnauthorized, "Unauthorized"))
 		return
 	}
 
@@ -53,7 +56,7 @@ func (h *LeagueHandler) CreateLeague(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Printf("CreateLeague error - Invalid request body: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Error(newValidationError(err))
 		return
 	}
 
@@ -65,14 +68,14 @@ func (h *LeagueHandler) CreateLeague(c *gin.Context) {
 
 	if err := h.db.Create(&league).Error; err != nil {
 		log.Printf("CreateLeague error - Database error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create league"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create league"))
 		return
 	}
 
 	// Load the owner information
 	if err := h.db.Preload("Owner").First(&league, league.ID).Error; err != nil {
 		log.Printf("CreateLeague error - Failed to load owner: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create league"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create league"))
 		return
 	}
 
@@ -84,26 +87,44 @@ func (h *LeagueHandler) CreateLeague(c *gin.Context) {
 
 // ListLeagues handles listing all leagues
 // @Summary List all leagues
-// @Description Get a list of all pinball leagues
+// @Description Get a page of pinball leagues, optionally filtered by location, owner or name prefix
 // @Tags leagues
 // @Produce json
-// @Success 200 {object} ListResponse{data=[]LeagueResponse} "List of leagues"
+// @Param location query string false "Only leagues at this location"
+// @Param ownerID query int false "Only leagues owned by this user"
+// @Param name query string false "Only leagues whose name starts with this, ignoring case"
+// @Param sort query string false "Comma separated sort fields, each optionally prefixed with - for descending: name, location, dateCreated"
+// @Param page query int false "Page number, from 1" default(1)
+// @Param limit query int false "Leagues per page, at most 100" default(50)
+// @Success 200 {object} PagedResponse{data=[]LeagueResponse} "Page of leagues"
+// @Failure 400 {object} ErrorResponse "Invalid filter, sort or page"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /leagues [get]
 func (h *LeagueHandler) ListLeagues(c *gin.Context) {
 	var leagues []models.League
-	if err := h.db.Preload("Owner").Find(&leagues).Error; err != nil {
-		log.Printf("ListLeagues error - Database error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch leagues"})
+	pagination, err := newListQuery(c, h.db, leagueSortFields).
+		Equal("location", "location").
+		ID("ownerID", "owner_id").
+		Prefix("name", "name").
+		Find(&leagues, "Owner")
+	if err != nil {
+		respondWithError(c, "ListLeagues", "Failed to fetch leagues", err)
 		return
 	}
 
-	log.Printf("ListLeagues success - Retrieved %d leagues", len(leagues))
+	log.Printf("ListLeagues success - Retrieved %d of %d leagues", len(leagues), pagination.Total)
 	c.JSON(http.StatusOK, gin.H{
-		"data": leagues,
+		"data":       leagues,
+		"pagination": pagination,
 	})
 }
 
+var leagueSortFields = map[string]string{
+	"name":        "name",
+	"location":    "location",
+	"dateCreated": "date_created",
+}
+
 // GetLeague handles getting a single league by ID
 // @Summary Get league by ID
 // @Description Get detailed information about a specific league
@@ -112,20 +133,15 @@ func (h *LeagueHandler) ListLeagues(c *gin.Context) {
 // @Param leagueID path string true "League ID"
 // @Success 200 {object} ListResponse{data=LeagueResponse} "League details"
 // @Failure 400 {object} ErrorResponse "Invalid league ID"
+// @Failure 404 {object} ErrorResponse "League not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /leagues/{leagueID} [get]
 func (h *LeagueHandler) GetLeague(c *gin.Context) {
-	leagueID := c.Param("leagueID")
-	if leagueID == "" {
-		log.Printf("GetLeague error - No league ID provided")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No league ID provided"})
-		return
-	}
+	league := *c.MustGet("league").(*models.League)
 
-	var league models.League
-	if err := h.db.Preload("Owner").First(&league, leagueID).Error; err != nil {
+	if err := h.db.First(&league.Owner, league.OwnerID).Error; err != nil {
 		log.Printf("GetLeague error - Database error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch league"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch league owner"))
 		return
 	}
 
@@ -137,112 +153,341 @@ func (h *LeagueHandler) GetLeague(c *gin.Context) {
 
 // ListPlayers handles listing all players in a league
 // @Summary List players in a league
-// @Description Get a list of all players in a specific league
+// @Description Get a page of the players in a specific league, optionally filtered by name prefix. Archived players are left out unless includeArchived is set.
 // @Tags leagues
 // @Produce json
 // @Param leagueID path string true "League ID"
-// @Success 200 {object} ListResponse{data=[]PlayerResponse} "List of players"
-// @Failure 400 {object} ErrorResponse "Invalid league ID"
+// @Param includeArchived query bool false "Include archived players"
+// @Param name query string false "Only players whose name starts with this, ignoring case"
+// @Param sort query string false "Comma separated sort fields, each optionally prefixed with - for descending: name, ifpaRank, ifpaPoints"
+// @Param page query int false "Page number, from 1" default(1)
+// @Param limit query int false "Players per page, at most 100" default(50)
+// @Success 200 {object} PagedResponse{data=[]PlayerResponse} "Page of players"
+// @Failure 400 {object} ErrorResponse "Invalid league ID, filter, sort or page"
+// @Failure 404 {object} ErrorResponse "League not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /leagues/{leagueID}/players [get]
 func (h *LeagueHandler) ListPlayers(c *gin.Context) {
-	leagueID := c.Param("leagueID")
-	if leagueID == "" {
-		log.Printf("ListPlayers error - No league ID provided")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "League ID is required"})
-		return
+	league := c.MustGet("league").(*models.League)
+
+	query := h.db.Where("league_id = ?", league.ID)
+	if c.Query("includeArchived") != "true" {
+		query = query.Where("is_archived = ?", false)
 	}
 
 	var players []models.Player
-	if err := h.db.Where("league_id = ?", leagueID).Find(&players).Error; err != nil {
-		log.Printf("ListPlayers error - Database error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch players"})
+	pagination, err := newListQuery(c, query, playerSortFields).
+		Prefix("name", "name").
+		Find(&players)
+	if err != nil {
+		respondWithError(c, "ListPlayers", "Failed to fetch players", err)
 		return
 	}
 
-	log.Printf("ListPlayers success - Retrieved %d players for league %s", len(players), leagueID)
+	log.Printf("ListPlayers success - Retrieved %d of %d players for league %d", len(players), pagination.Total, league.ID)
 	c.JSON(http.StatusOK, gin.H{
-		"data": players,
+		"data":       players,
+		"pagination": pagination,
 	})
 }
 
+var playerSortFields = map[string]string{
+	"name":       "name",
+	"ifpaRank":   "ifpa_rank",
+	"ifpaPoints": "ifpa_points",
+}
+
+// ifpaLookupWorkers bounds the concurrent IFPA requests made by a bulk add
+const ifpaLookupWorkers = 4
+
 // AddPlayersByIFPA handles adding players to a league by their IFPA numbers
 // @Summary Add players to league by IFPA numbers
-// @Description Add multiple players to a league using their IFPA numbers
+// @Description Look up each IFPA number concurrently and add the players found to the league in a single transaction. Every number gets its own result: ADDED, ALREADY_PRESENT, NOT_FOUND or ERROR, so one failed lookup does not stop the rest. Duplicate numbers are reported once.
 // @Tags leagues
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param leagueID path string true "League ID"
-// @Param request body object true "IFPA numbers" {"ifpaNumbers": [1234, 5678]}
-// @Success 200 {object} ListResponse{data=[]PlayerResponse} "Players added successfully"
+// @Param request body models.AddPlayersByIFPARequest true "IFPA numbers"
+// @Success 200 {object} ListResponse{data=[]IFPAImportResponse} "Result for each IFPA number"
 // @Failure 400 {object} ErrorResponse "Invalid request body or league ID"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "IFPA integration is disabled"
 // @Router /leagues/{leagueID}/players/ifpa [post]
 func (h *LeagueHandler) AddPlayersByIFPA(c *gin.Context) {
-	leagueID := c.Param("leagueID")
-	if leagueID == "" {
-		log.Printf("AddPlayersByIFPA error - No league ID provided")
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "League ID is required"})
+	league := c.MustGet("league").(*models.League)
+
+	if !h.ifpaService.Enabled() {
+		c.Error(NewAPIError(http.StatusServiceUnavailable, CodeIntegrationDisabled, "IFPA integration is disabled"))
 		return
 	}
 
-	// Convert leagueID to uint
-	leagueIDUint, err := strconv.ParseUint(leagueID, 10, 32)
-	if err != nil {
-		log.Printf("AddPlayersByIFPA error - Invalid league ID: %v", err)
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid league ID"})
+	var req models.AddPlayersByIFPARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("AddPlayersByIFPA error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
 		return
 	}
 
-	var req struct {
-		IFPANumbers []int `json:"ifpaNumbers" binding:"required"`
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, number := range req.IFPANumbers {
+		if !seen[number] {
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("AddPlayersByIFPA error - Invalid request body: %v", err)
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request body"})
+	results := make([]IFPAImportResponse, len(numbers))
+	existing, err := playersByIFPANumber(h.db, league.ID, numbers)
+	if err != nil {
+		log.Printf("AddPlayersByIFPA error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to check existing players"))
 		return
 	}
 
-	var addedPlayers []models.Player
-	for _, ifpaNumber := range req.IFPANumbers {
-		// Check if player already exists in the league
-		var existingPlayer models.Player
-		result := h.db.Where("league_id = ? AND ifpa_number = ?", leagueIDUint, strconv.Itoa(ifpaNumber)).First(&existingPlayer)
-		if result.Error == nil {
-			// Player already exists in the league
-			addedPlayers = append(addedPlayers, existingPlayer)
+	// Only numbers not already in the league are looked up
+	var toLookUp []int
+	positions := make(map[int]int, len(numbers))
+	for i, number := range numbers {
+		positions[number] = i
+		results[i].IFPANumber = number
+		if player, ok := existing[number]; ok {
+			results[i].Status = models.IFPAImportAlreadyPresent
+			results[i].Player = player
 			continue
 		}
+		toLookUp = append(toLookUp, number)
+	}
+
+	var found []services.IFPALookup
+	for _, lookup := range services.LookupIFPAPlayers(h.ifpaService, toLookUp, ifpaLookupWorkers) {
+		result := &results[positions[lookup.IFPANumber]]
+		switch {
+		case errors.Is(lookup.Err, services.ErrIFPAPlayerNotFound):
+			result.Status = models.IFPAImportNotFound
+		case lookup.Err != nil:
+			log.Printf("AddPlayersByIFPA error - Failed to get IFPA player %d: %v", lookup.IFPANumber, lookup.Err)
+			result.Status = models.IFPAImportError
+			result.Error = fmt.Sprintf("Failed to get IFPA player %d", lookup.IFPANumber)
+		default:
+			found = append(found, lookup)
+		}
+	}
 
-		// Get player details from IFPA API
-		ifpaPlayer, err := h.ifpaService.GetPlayerByIFPANumber(ifpaNumber)
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		// Another request may have added some of the players since the first check
+		lookedUp := make([]int, len(found))
+		for i, lookup := range found {
+			lookedUp[i] = lookup.IFPANumber
+		}
+		added, err := playersByIFPANumber(tx, league.ID, lookedUp)
 		if err != nil {
-			log.Printf("AddPlayersByIFPA error - Failed to get IFPA player %d: %v", ifpaNumber, err)
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to get IFPA player %d", ifpaNumber)})
-			return
+			return err
 		}
 
-		// Create new player
-		player := models.Player{
-			LeagueID:   uint(leagueIDUint),
-			IFPANumber: strconv.Itoa(ifpaNumber),
-			Name:       fmt.Sprintf("%s %s", ifpaPlayer.FirstName, ifpaPlayer.LastName),
+		for _, lookup := range found {
+			result := &results[positions[lookup.IFPANumber]]
+			if player, ok := added[lookup.IFPANumber]; ok {
+				result.Status = models.IFPAImportAlreadyPresent
+				result.Player = player
+				continue
+			}
+
+			player := models.Player{
+				LeagueID:   league.ID,
+				IFPANumber: strconv.Itoa(lookup.IFPANumber),
+				Name:       fmt.Sprintf("%s %s", lookup.Player.FirstName, lookup.Player.LastName),
+			}
+			if err := tx.Create(&player).Error; err != nil {
+				return err
+			}
+			result.Status = models.IFPAImportAdded
+			result.Player = &player
 		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("AddPlayersByIFPA error - Failed to create players: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create players"))
+		return
+	}
+
+	addedCount := 0
+	for _, result := range results {
+		if result.Status == models.IFPAImportAdded {
+			addedCount++
+		}
+	}
+	log.Printf("AddPlayersByIFPA success - Added %d players to league %d", addedCount, league.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": results,
+	})
+}
+
+// playersByIFPANumber loads the league's players with any of the given IFPA
+// numbers, keyed by number
+func playersByIFPANumber(tx *gorm.DB, leagueID uint, numbers []int) (map[int]*models.Player, error) {
+	players := make(map[int]*models.Player)
+	if len(numbers) == 0 {
+		return players, nil
+	}
+
+	keys := make([]string, len(numbers))
+	for i, number := range numbers {
+		keys[i] = strconv.Itoa(number)
+	}
+
+	var matches []models.Player
+	if err := tx.Where("league_id = ? AND ifpa_number IN ?", leagueID, keys).Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		if number, err := strconv.Atoi(matches[i].IFPANumber); err == nil {
+			players[number] = &matches[i]
+		}
+	}
+	return players, nil
+}
+
+// SyncIFPAPlayers handles refreshing the IFPA figures of a league's players
+// @Summary Sync IFPA rankings
+// @Description Fetch the current IFPA rank, WPPR points and rating for every player in the league with an IFPA number and store them on the player. Requests are rate limited and temporary failures are retried; players who still fail are listed in the result and keep their previous figures.
+// @Tags players
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Success 200 {object} ListResponse{data=services.IFPASyncResult} "Sync finished"
+// @Failure 400 {object} ErrorResponse "Invalid league ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League not found"
+// @Failure 409 {object} ErrorResponse "A sync is already running for this league"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "IFPA integration is disabled"
+// @Router /leagues/{leagueID}/players/ifpa-sync [post]
+func (h *LeagueHandler) SyncIFPAPlayers(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	result, err := h.ifpaSync.SyncLeague(league.ID)
+	switch {
+	case errors.Is(err, services.ErrIFPADisabled):
+		c.Error(NewAPIError(http.StatusServiceUnavailable, CodeIntegrationDisabled, "IFPA integration is disabled"))
+		return
+	case errors.Is(err, services.ErrSyncInProgress):
+		c.Error(NewAPIError(http.StatusConflict, CodeConflict, "A sync is already running for this league"))
+		return
+	case err != nil:
+		log.Printf("SyncIFPAPlayers error - Failed to sync league %d: %v", league.ID, err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to sync IFPA players"))
+		return
+	}
+
+	log.Printf("SyncIFPAPlayers success - Synced %d players in league %d, %d failed", result.Synced, league.ID, len(result.Failures))
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// UpdateLeague handles changing a league's details
+// @Summary Update a league
+// @Description Change a league's name or location. Omitted fields are left unchanged.
+// @Tags leagues
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param request body models.UpdateLeagueRequest true "League changes"
+// @Success 200 {object} ListResponse{data=LeagueResponse} "League updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or league ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID} [patch]
+func (h *LeagueHandler) UpdateLeague(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	var req models.UpdateLeagueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("UpdateLeague error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
 
-		if err := h.db.Create(&player).Error; err != nil {
-			log.Printf("AddPlayersByIFPA error - Failed to create player: %v", err)
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create player"})
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Location != nil {
+		updates["location"] = *req.Location
+	}
+
+	if len(updates) > 0 {
+		if err := h.db.Model(league).Updates(updates).Error; err != nil {
+			log.Printf("UpdateLeague error - Database error: %v", err)
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to update league"))
 			return
 		}
+	}
 
-		addedPlayers = append(addedPlayers, player)
+	if err := h.db.First(&league.Owner, league.OwnerID).Error; err != nil {
+		log.Printf("UpdateLeague error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch league owner"))
+		return
 	}
 
-	log.Printf("AddPlayersByIFPA success - Added %d players to league %d", len(addedPlayers), leagueIDUint)
+	log.Printf("UpdateLeague success - League %d updated", league.ID)
 	c.JSON(http.StatusOK, gin.H{
-		"data": addedPlayers,
+		"data": league,
 	})
 }
+
+// DeleteLeague handles deleting a league
+// @Summary Delete a league
+// @Description Delete a league along with its seasons, events, players, machine bank and staff. Leagues with any recorded results cannot be deleted. Only the league owner may delete a league.
+// @Tags leagues
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Success 204 "League deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid league ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League not found"
+// @Failure 409 {object} ErrorResponse "League has recorded results"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID} [delete]
+func (h *LeagueHandler) DeleteLeague(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var seasonIDs []uint
+		if err := tx.Model(&models.Season{}).Where("league_id = ?", league.ID).Pluck("id", &seasonIDs).Error; err != nil {
+			return err
+		}
+		if err := deleteSeasons(tx, seasonIDs); err != nil {
+			return err
+		}
+		if err := tx.Where("league_id = ?", league.ID).Delete(&models.Player{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("league_id = ?", league.ID).Delete(&models.LeagueMachine{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("league_id = ?", league.ID).Delete(&models.LeagueMembership{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(league).Error
+	})
+	if err != nil {
+		respondWithError(c, "DeleteLeague", "Failed to delete", err)
+		return
+	}
+
+	log.Printf("DeleteLeague success - League %d deleted", league.ID)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+// ResourceLoader loads the league, season, event and player named in a
+// request path so handlers and the Authorizer don't each look them up again
+type ResourceLoader struct {
+	db *gorm.DB
+}
+
+func NewResourceLoader(db *gorm.DB) *ResourceLoader {
+	return &ResourceLoader{db: db}
+}
+
+// LoadPathResources is middleware that loads every resource named by a
+// leagueID, seasonID, eventID or playerID path parameter and stores them in
+// the context under "league", "season", "event" and "player". Parents missing
+// from the path are loaded from the child, so /events/:eventID still sets the
+// event's season and league. A child that does not belong to the parent in
+// the path is answered with 404, exactly as if it did not exist.
+func (l *ResourceLoader) LoadPathResources(c *gin.Context) {
+	var event *models.Event
+	if c.Param("eventID") != "" {
+		event = &models.Event{}
+		if !l.loadFromPath(c, event, "eventID", "Event") {
+			return
+		}
+	}
+
+	var season *models.Season
+	switch {
+	case c.Param("seasonID") != "":
+		season = &models.Season{}
+		if !l.loadFromPath(c, season, "seasonID", "Season") {
+			return
+		}
+		if event != nil && event.SeasonID != season.ID {
+			c.Error(NewAPIError(http.StatusNotFound, CodeEventNotFound, "Event not found"))
+			c.Abort()
+			return
+		}
+	case event != nil:
+		season = &models.Season{}
+		if !l.loadByID(c, season, event.SeasonID, "Season") {
+			return
+		}
+	}
+
+	var league *models.League
+	switch {
+	case c.Param("leagueID") != "":
+		league = &models.League{}
+		if !l.loadFromPath(c, league, "leagueID", "League") {
+			return
+		}
+		if season != nil && season.LeagueID != league.ID {
+			c.Error(NewAPIError(http.StatusNotFound, CodeSeasonNotFound, "Season not found"))
+			c.Abort()
+			return
+		}
+	case season != nil:
+		league = &models.League{}
+		if !l.loadByID(c, league, season.LeagueID, "League") {
+			return
+		}
+	}
+
+	if c.Param("playerID") != "" {
+		player := &models.Player{}
+		if !l.loadFromPath(c, player, "playerID", "Player") {
+			return
+		}
+		if league != nil && player.LeagueID != league.ID {
+			c.Error(NewAPIError(http.StatusNotFound, CodePlayerNotFound, "Player not found"))
+			c.Abort()
+			return
+		}
+		if league == nil {
+			league = &models.League{}
+			if !l.loadByID(c, league, player.LeagueID, "League") {
+				return
+			}
+		}
+		c.Set("player", player)
+	}
+
+	if league != nil {
+		c.Set("league", league)
+	}
+	if season != nil {
+		c.Set("season", season)
+	}
+	if event != nil {
+		c.Set("event", event)
+	}
+	c.Next()
+}
+
+// loadFromPath loads the record whose ID is in the named path parameter,
+// aborting the request and returning false if it can't
+func (l *ResourceLoader) loadFromPath(c *gin.Context, dest interface{}, param, name string) bool {
+	id, err := parsePathID(c, param)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeInvalidID, "Invalid "+strings.ToLower(name)+" ID"))
+		c.Abort()
+		return false
+	}
+	return l.loadByID(c, dest, id, name)
+}
+
+// loadByID loads a record by primary key, aborting the request and returning
+// false if it can't
+func (l *ResourceLoader) loadByID(c *gin.Context, dest interface{}, id uint, name string) bool {
+	err := l.db.First(dest, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Error(NewAPIError(http.StatusNotFound, strings.ToUpper(name)+"_NOT_FOUND", name+" not found"))
+		c.Abort()
+		return false
+	}
+	if err != nil {
+		log.Printf("LoadPathResources error - Failed to load %s %d: %v", strings.ToLower(name), id, err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch "+strings.ToLower(name)))
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// parsePathID parses a numeric ID from the named path parameter
+func parsePathID(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+// Deprecated is middleware marking a response as coming from an old route
+// that has been replaced by the /api/v1 route tree
+func Deprecated(c *gin.Context) {
+	c.Header("Deprecation", "true")
+	c.Header("Link", `</api/v1>; rel="successor-version"`)
+	c.Next()
+}
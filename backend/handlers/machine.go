@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"backend/services"
 
@@ -9,10 +14,10 @@ import (
 )
 
 type MachineHandler struct {
-	opdbService *services.OPDBService
+	opdbService services.MachineCatalog
 }
 
-func NewMachineHandler(opdbService *services.OPDBService) *MachineHandler {
+func NewMachineHandler(opdbService services.MachineCatalog) *MachineHandler {
 	return &MachineHandler{
 		opdbService: opdbService,
 	}
@@ -20,28 +25,86 @@ func NewMachineHandler(opdbService *services.OPDBService) *MachineHandler {
 
 // GetMachine godoc
 // @Summary Get machine details from OPDB
-// @Description Get machine details from OPDB API and cache in database
+// @Description Get machine details from OPDB API and cache in database. A stale cached copy is returned if OPDB cannot be reached.
 // @Tags machines
 // @Accept json
 // @Produce json
 // @Param opdb_id path string true "OPDB ID"
 // @Success 200 {object} models.Machine
 // @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "Machine not found in OPDB"
 // @Failure 500 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse "OPDB unreachable and machine not cached"
+// @Failure 503 {object} ErrorResponse "OPDB integration is disabled"
 // @Router /machines/{opdb_id} [get]
 func (h *MachineHandler) GetMachine(c *gin.Context) {
 	opdbID := c.Param("opdb_id")
 	if opdbID == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "OPDB ID is required"})
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, "OPDB ID is required"))
 		return
 	}
 
 	machine, err := h.opdbService.GetMachine(opdbID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondWithMachineLookupError(c, "GetMachine", opdbID, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, machine)
 }
+
+// SearchMachines godoc
+// @Summary Search machines
+// @Description Search OPDB and the locally cached machines by name, merged and ranked by relevance. Local matches are still returned if OPDB cannot be reached.
+// @Tags machines
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text"
+// @Param limit query int false "Maximum number of results (default 20, max 50)"
+// @Success 200 {object} map[string][]services.MachineSearchResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /machines/search [get]
+func (h *MachineHandler) SearchMachines(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.Error(invalidField("q", "required", "q is required"))
+		return
+	}
+
+	limit := 20
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > 50 {
+			c.Error(invalidField("limit", "range", "limit must be between 1 and 50"))
+			return
+		}
+		limit = parsed
+	}
+
+	results, err := h.opdbService.SearchMachines(query, limit)
+	if results == nil && err != nil {
+		log.Printf("SearchMachines error - Failed to search machines: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to search machines"))
+		return
+	}
+	if err != nil {
+		log.Printf("SearchMachines warning - OPDB search failed, returning local results only: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// respondWithMachineLookupError maps a MachineCatalog.GetMachine error to a
+// response
+func respondWithMachineLookupError(c *gin.Context, action, opdbID string, err error) {
+	switch {
+	case errors.Is(err, services.ErrMachineNotFound):
+		c.Error(NewAPIError(http.StatusNotFound, CodeMachineNotFound, fmt.Sprintf("Machine %s not found in OPDB", opdbID)))
+	case errors.Is(err, services.ErrOPDBDisabled):
+		c.Error(NewAPIError(http.StatusServiceUnavailable, CodeIntegrationDisabled, "OPDB integration is disabled"))
+	default:
+		log.Printf("%s error - Failed to get machine %s: %v", action, opdbID, err)
+		c.Error(NewAPIError(http.StatusBadGateway, CodeUpstreamFailed, fmt.Sprintf("Failed to get machine %s", opdbID)))
+	}
+}
@@ -0,0 +1,466 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+	"backend/services"
+)
+
+type MachineBankHandler struct {
+	db          *gorm.DB
+	opdbService services.MachineCatalog
+}
+
+func NewMachineBankHandler(db *gorm.DB, opdbService services.MachineCatalog) *MachineBankHandler {
+	return &MachineBankHandler{
+		db:          db,
+		opdbService: opdbService,
+	}
+}
+
+// ListLeagueMachines handles listing a league's machine bank
+// @Summary List a league's machines
+// @Description Get the machines in a league's bank, including whether each is in order
+// @Tags machines
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Success 200 {object} ListResponse{data=[]LeagueMachineResponse} "League machines"
+// @Failure 400 {object} ErrorResponse "Invalid league ID"
+// @Failure 404 {object} ErrorResponse "League not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/machines [get]
+func (h *MachineBankHandler) ListLeagueMachines(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	var bank []models.LeagueMachine
+	if err := h.db.Where("league_id = ?", league.ID).Preload("Machine").Order("id").Find(&bank).Error; err != nil {
+		log.Printf("ListLeagueMachines error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch machines"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": bank,
+	})
+}
+
+// AddLeagueMachine handles adding a machine to a league's bank
+// @Summary Add a machine to a league
+// @Description Add a machine to a league's bank by its OPDB ID. Machine details are fetched from OPDB if they are not already cached.
+// @Tags machines
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param request body models.AddLeagueMachineRequest true "Machine to add"
+// @Success 201 {object} ListResponse{data=LeagueMachineResponse} "Machine added successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or league ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League or machine not found"
+// @Failure 409 {object} ErrorResponse "Machine is already in the league's bank"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "OPDB unreachable and machine not cached"
+// @Failure 503 {object} ErrorResponse "OPDB integration is disabled"
+// @Router /leagues/{leagueID}/machines [post]
+func (h *MachineBankHandler) AddLeagueMachine(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	var req models.AddLeagueMachineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("AddLeagueMachine error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	machine, err := h.opdbService.GetMachine(req.OPDBID)
+	if err != nil {
+		respondWithMachineLookupError(c, "AddLeagueMachine", req.OPDBID, err)
+		return
+	}
+
+	var count int64
+	if err := h.db.Model(&models.LeagueMachine{}).Where("league_id = ? AND machine_id = ?", league.ID, machine.ID).Count(&count).Error; err != nil {
+		log.Printf("AddLeagueMachine error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to check machines"))
+		return
+	}
+	if count > 0 {
+		c.Error(NewAPIError(http.StatusConflict, CodeAlreadyExists, "Machine is already in the league's bank"))
+		return
+	}
+
+	entry := models.LeagueMachine{
+		LeagueID:  league.ID,
+		MachineID: machine.ID,
+		Machine:   *machine,
+		IsActive:  true,
+		Notes:     req.Notes,
+	}
+	if err := h.db.Omit("Machine").Create(&entry).Error; err != nil {
+		log.Printf("AddLeagueMachine error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to add machine"))
+		return
+	}
+
+	log.Printf("AddLeagueMachine success - Machine %s added to league %d", machine.Name, league.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"data": entry,
+	})
+}
+
+// UpdateLeagueMachine handles marking a machine in or out of order
+// @Summary Update a league machine
+// @Description Mark a machine in the league's bank in or out of order, or change its notes. Marking a machine out of order moves any unplayed games scheduled on it, in events that are not complete, onto the event's other machines.
+// @Tags machines
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param machineID path string true "Machine ID"
+// @Param request body models.UpdateLeagueMachineRequest true "Machine changes"
+// @Success 200 {object} ListResponse{data=LeagueMachineResponse} "Machine updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body, league ID or machine ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Machine not in the league's bank"
+// @Failure 409 {object} ErrorResponse "An event has no other machine to take the machine's games"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/machines/{machineID} [patch]
+func (h *MachineBankHandler) UpdateLeagueMachine(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	var req models.UpdateLeagueMachineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("UpdateLeagueMachine error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	var entry models.LeagueMachine
+	moved := 0
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := loadLeagueMachine(tx, league.ID, c.Param("machineID"), &entry); err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if req.Notes != nil {
+			updates["notes"] = *req.Notes
+		}
+		if req.IsActive != nil {
+			updates["is_active"] = *req.IsActive
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&entry).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		if entry.IsActive {
+			return nil
+		}
+
+		var events []models.Event
+		if err := tx.Joins("JOIN seasons ON seasons.id = events.season_id AND seasons.deleted_at IS NULL").
+			Joins("JOIN event_machines ON event_machines.event_id = events.id").
+			Where("seasons.league_id = ? AND events.is_complete = ? AND event_machines.machine_id = ?", league.ID, false, entry.MachineID).
+			Find(&events).Error; err != nil {
+			return err
+		}
+		for i := range events {
+			count, err := dropMachineFromSchedule(tx, &events[i], league.ID, entry.MachineID)
+			if err != nil {
+				return err
+			}
+			moved += count
+		}
+		return nil
+	})
+	if err != nil {
+		respondWithError(c, "UpdateLeagueMachine", "Failed to update machine", err)
+		return
+	}
+
+	log.Printf("UpdateLeagueMachine success - Machine %d in league %d updated, %d scheduled games moved", entry.MachineID, league.ID, moved)
+	c.JSON(http.StatusOK, gin.H{
+		"data": entry,
+	})
+}
+
+// RemoveLeagueMachine handles removing a machine from a league's bank
+// @Summary Remove a machine from a league
+// @Description Remove a machine from a league's bank. Machines still attached to an event that is not complete cannot be removed.
+// @Tags machines
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param machineID path string true "Machine ID"
+// @Success 204 "Machine removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid league ID or machine ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Machine not in the league's bank"
+// @Failure 409 {object} ErrorResponse "Machine is attached to an event that is not complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/machines/{machineID} [delete]
+func (h *MachineBankHandler) RemoveLeagueMachine(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	var entry models.LeagueMachine
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := loadLeagueMachine(tx, league.ID, c.Param("machineID"), &entry); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Event{}).
+			Joins("JOIN seasons ON seasons.id = events.season_id AND seasons.deleted_at IS NULL").
+			Joins("JOIN event_machines ON event_machines.event_id = events.id").
+			Where("seasons.league_id = ? AND events.is_complete = ? AND event_machines.machine_id = ?", league.ID, false, entry.MachineID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return NewAPIError(http.StatusConflict, CodeConflict, "Machine is attached to an event that is not complete")
+		}
+
+		// Bank entries are removed outright so the machine can be added again later
+		return tx.Unscoped().Delete(&entry).Error
+	})
+	if err != nil {
+		respondWithError(c, "RemoveLeagueMachine", "Failed to remove machine", err)
+		return
+	}
+
+	log.Printf("RemoveLeagueMachine success - Machine %d removed from league %d", entry.MachineID, league.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// ListEventMachines handles listing the machines attached to an event
+// @Summary List an event's machines
+// @Description Get the machines from the league's bank that are attached to an event, including whether each is in order
+// @Tags machines
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=[]LeagueMachineResponse} "Event machines"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/machines [get]
+func (h *MachineBankHandler) ListEventMachines(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+	event := c.MustGet("event").(*models.Event)
+	h.respondWithEventMachines(c, event.ID, league.ID)
+}
+
+// AttachEventMachines handles adding machines from the league's bank to an event
+// @Summary Add machines to an event
+// @Description Attach machines from the league's bank to an event. Every machine must be in the bank and in order. Machines already attached are left as they are.
+// @Tags machines
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.AttachMachinesRequest true "Machines to attach"
+// @Success 200 {object} ListResponse{data=[]LeagueMachineResponse} "Machines attached successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body, or machines not in order in the league's bank"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event is complete"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/machines [post]
+func (h *MachineBankHandler) AttachEventMachines(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	var req models.AttachMachinesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("AttachEventMachines error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	event := c.MustGet("event").(*models.Event)
+	if err := checkEventOpen(event); err != nil {
+		c.Error(err)
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var bank []models.LeagueMachine
+		if err := tx.Where("league_id = ? AND machine_id IN ? AND is_active = ?", league.ID, req.MachineIDs, true).
+			Preload("Machine").
+			Find(&bank).Error; err != nil {
+			return err
+		}
+		available := make(map[uint]models.Machine, len(bank))
+		for _, entry := range bank {
+			available[entry.MachineID] = entry.Machine
+		}
+
+		machines := make([]models.Machine, 0, len(req.MachineIDs))
+		for _, machineID := range req.MachineIDs {
+			machine, ok := available[machineID]
+			if !ok {
+				return NewAPIError(http.StatusBadRequest, CodeBadRequest, fmt.Sprintf("Machine %d is not an in-order machine in this league's bank", machineID))
+			}
+			machines = append(machines, machine)
+		}
+		return tx.Model(event).Omit("Machines.*").Association("Machines").Append(&machines)
+	})
+	if err != nil {
+		respondWithError(c, "AttachEventMachines", "Failed to attach machines", err)
+		return
+	}
+
+	log.Printf("AttachEventMachines success - Attached %d machines to event %d", len(req.MachineIDs), event.ID)
+	h.respondWithEventMachines(c, event.ID, league.ID)
+}
+
+// DetachEventMachine handles removing a machine from an event
+// @Summary Remove a machine from an event
+// @Description Detach a machine from an event. Unplayed games scheduled on it move to the event's other in-order machines, in the same round where a machine is free or in a later round otherwise.
+// @Tags machines
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param machineID path string true "Machine ID"
+// @Success 204 "Machine removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid event ID or machine ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found, or machine not attached to the event"
+// @Failure 409 {object} ErrorResponse "Event is complete, or no other machine can take the machine's games"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/machines/{machineID} [delete]
+func (h *MachineBankHandler) DetachEventMachine(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	machineID, err := strconv.ParseUint(c.Param("machineID"), 10, 32)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeInvalidID, "Invalid machine ID"))
+		return
+	}
+
+	event := c.MustGet("event").(*models.Event)
+	if err := checkEventOpen(event); err != nil {
+		c.Error(err)
+		return
+	}
+
+	moved := 0
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var attached int64
+		if err := tx.Table("event_machines").
+			Joins("JOIN league_machines ON league_machines.machine_id = event_machines.machine_id AND league_machines.league_id = ? AND league_machines.deleted_at IS NULL", league.ID).
+			Where("event_machines.event_id = ? AND event_machines.machine_id = ?", event.ID, machineID).
+			Count(&attached).Error; err != nil {
+			return err
+		}
+		if attached == 0 {
+			return NewAPIError(http.StatusNotFound, CodeMachineNotFound, "Machine is not attached to this event")
+		}
+		count, err := dropMachineFromSchedule(tx, event, league.ID, uint(machineID))
+		if err != nil {
+			return err
+		}
+		moved = count
+		return tx.Model(event).Association("Machines").Delete(&models.Machine{Model: gorm.Model{ID: uint(machineID)}})
+	})
+	if err != nil {
+		respondWithError(c, "DetachEventMachine", "Failed to remove machine", err)
+		return
+	}
+
+	log.Printf("DetachEventMachine success - Machine %d removed from event %d, %d scheduled games moved", machineID, event.ID, moved)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *MachineBankHandler) respondWithEventMachines(c *gin.Context, eventID, leagueID uint) {
+	var bank []models.LeagueMachine
+	if err := h.db.Where("league_id = ? AND machine_id IN (?)", leagueID,
+		h.db.Table("event_machines").Select("machine_id").Where("event_id = ?", eventID)).
+		Preload("Machine").
+		Order("id").
+		Find(&bank).Error; err != nil {
+		log.Printf("ListEventMachines error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch machines"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": bank,
+	})
+}
+
+// loadLeagueMachine loads the bank entry for the machine ID in the path
+func loadLeagueMachine(tx *gorm.DB, leagueID uint, machineIDParam string, entry *models.LeagueMachine) error {
+	machineID, err := strconv.ParseUint(machineIDParam, 10, 32)
+	if err != nil {
+		return NewAPIError(http.StatusBadRequest, CodeInvalidID, "Invalid machine ID")
+	}
+	if err := tx.Where("league_id = ? AND machine_id = ?", leagueID, machineID).Preload("Machine").First(entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NewAPIError(http.StatusNotFound, CodeMachineNotFound, "Machine is not in this league's bank")
+		}
+		return err
+	}
+	return nil
+}
+
+// checkEventOpen fails if the event's machines can no longer be changed
+// because it is complete
+func checkEventOpen(event *models.Event) error {
+	if event.IsComplete {
+		return NewAPIError(http.StatusConflict, CodeEventComplete, "Event is complete")
+	}
+	return nil
+}
+
+// activeEventMachines returns the machines attached to an event that are in
+// order in the league's bank
+func activeEventMachines(tx *gorm.DB, event *models.Event, leagueID uint) ([]models.Machine, error) {
+	var machines []models.Machine
+	err := tx.Model(event).Association("Machines").Find(&machines, "id IN (?)",
+		tx.Model(&models.LeagueMachine{}).Select("machine_id").Where("league_id = ? AND is_active = ?", leagueID, true))
+	return machines, err
+}
+
+// dropMachineFromSchedule moves an event's unplayed scheduled games off a
+// machine onto the event's other in-order machines, returning how many moved
+func dropMachineFromSchedule(tx *gorm.DB, event *models.Event, leagueID, machineID uint) (int, error) {
+	var schedule []models.ScheduledGame
+	if err := tx.Where("event_id = ?", event.ID).Find(&schedule).Error; err != nil {
+		return 0, err
+	}
+
+	machines, err := activeEventMachines(tx, event, leagueID)
+	if err != nil {
+		return 0, err
+	}
+
+	moved, err := services.DropMachine(schedule, machineID, machines)
+	if err != nil {
+		return 0, NewAPIError(http.StatusConflict, CodeConflict, fmt.Sprintf("Event %d: %v", event.ID, err))
+	}
+	for _, i := range moved {
+		if err := tx.Model(&schedule[i]).Select("round", "machine_id").Updates(&schedule[i]).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(moved), nil
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+type MemberHandler struct {
+	db *gorm.DB
+}
+
+func NewMemberHandler(db *gorm.DB) *MemberHandler {
+	return &MemberHandler{db: db}
+}
+
+// ListMembers handles listing a league's staff
+// @Summary List league staff
+// @Description List the owner, admins and scorekeepers of a league. Only league staff may see the list.
+// @Tags members
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Success 200 {object} ListResponse{data=[]MemberResponse} "League staff"
+// @Failure 400 {object} ErrorResponse "Invalid league ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not on the league staff"
+// @Failure 404 {object} ErrorResponse "League not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/members [get]
+func (h *MemberHandler) ListMembers(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	if err := h.db.First(&league.Owner, league.OwnerID).Error; err != nil {
+		log.Printf("ListMembers error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch league owner"))
+		return
+	}
+
+	var memberships []models.LeagueMembership
+	if err := h.db.Where("league_id = ?", league.ID).Preload("User").Order("id").Find(&memberships).Error; err != nil {
+		log.Printf("ListMembers error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch members"))
+		return
+	}
+
+	// The owner is recorded on the league itself rather than as a membership
+	members := append([]models.LeagueMembership{{
+		LeagueID: league.ID,
+		UserID:   league.OwnerID,
+		User:     league.Owner,
+		Role:     models.LeagueRoleOwner,
+	}}, memberships...)
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": members,
+	})
+}
+
+// AddMember handles inviting a user to a league's staff
+// @Summary Add a league staff member
+// @Description Give an existing user an admin or scorekeeper role in a league, looked up by email. Admins may add scorekeepers; only the owner may add admins.
+// @Tags members
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param request body models.AddLeagueMemberRequest true "Member details"
+// @Success 201 {object} ListResponse{data=MemberResponse} "Member added successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or league ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not allowed to grant this role"
+// @Failure 404 {object} ErrorResponse "League or user not found"
+// @Failure 409 {object} ErrorResponse "User is already on the league staff"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/members [post]
+func (h *MemberHandler) AddMember(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+	currentRole := c.MustGet("leagueRole").(models.LeagueRole)
+
+	var req models.AddLeagueMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("AddMember error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	if !canManageRole(currentRole, req.Role) {
+		c.Error(NewAPIError(http.StatusForbidden, CodeForbidden, "Only the league owner can add admins"))
+		return
+	}
+
+	var user models.User
+	if err := h.db.Where("email = ?", req.Email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Error(NewAPIError(http.StatusNotFound, CodeUserNotFound, "No user with that email address"))
+			return
+		}
+		log.Printf("AddMember error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch user"))
+		return
+	}
+
+	if user.ID == league.OwnerID {
+		c.Error(NewAPIError(http.StatusConflict, CodeAlreadyExists, "User already owns this league"))
+		return
+	}
+
+	var count int64
+	if err := h.db.Model(&models.LeagueMembership{}).Where("league_id = ? AND user_id = ?", league.ID, user.ID).Count(&count).Error; err != nil {
+		log.Printf("AddMember error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to check membership"))
+		return
+	}
+	if count > 0 {
+		c.Error(NewAPIError(http.StatusConflict, CodeAlreadyExists, "User is already on the league staff"))
+		return
+	}
+
+	membership := models.LeagueMembership{
+		LeagueID: league.ID,
+		UserID:   user.ID,
+		User:     user,
+		Role:     req.Role,
+	}
+	if err := h.db.Omit("User").Create(&membership).Error; err != nil {
+		log.Printf("AddMember error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to add member"))
+		return
+	}
+
+	log.Printf("AddMember success - User %d added to league %d as %s", user.ID, league.ID, membership.Role)
+	c.JSON(http.StatusCreated, gin.H{
+		"data": membership,
+	})
+}
+
+// RemoveMember handles removing a user from a league's staff
+// @Summary Remove a league staff member
+// @Description Remove an admin or scorekeeper from a league. Admins may remove scorekeepers; only the owner may remove admins. The owner cannot be removed.
+// @Tags members
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param userID path string true "User ID"
+// @Success 204 "Member removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid league or user ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Not allowed to remove this member"
+// @Failure 404 {object} ErrorResponse "League or member not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/members/{userID} [delete]
+func (h *MemberHandler) RemoveMember(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+	currentRole := c.MustGet("leagueRole").(models.LeagueRole)
+
+	userID, err := strconv.ParseUint(c.Param("userID"), 10, 32)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeInvalidID, "Invalid user ID"))
+		return
+	}
+
+	if uint(userID) == league.OwnerID {
+		c.Error(NewAPIError(http.StatusForbidden, CodeForbidden, "The league owner cannot be removed"))
+		return
+	}
+
+	var membership models.LeagueMembership
+	if err := h.db.Where("league_id = ? AND user_id = ?", league.ID, userID).First(&membership).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Error(NewAPIError(http.StatusNotFound, CodeMemberNotFound, "Member not found"))
+			return
+		}
+		log.Printf("RemoveMember error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch member"))
+		return
+	}
+
+	if !canManageRole(currentRole, membership.Role) {
+		c.Error(NewAPIError(http.StatusForbidden, CodeForbidden, "Only the league owner can remove admins"))
+		return
+	}
+
+	// Memberships are removed outright so the user can be invited again later
+	if err := h.db.Unscoped().Delete(&membership).Error; err != nil {
+		log.Printf("RemoveMember error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to remove member"))
+		return
+	}
+
+	log.Printf("RemoveMember success - User %d removed from league %d", userID, league.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// canManageRole reports whether a staff member with the given role may add or
+// remove members with the target role. Only the owner manages admins.
+func canManageRole(current, target models.LeagueRole) bool {
+	if target == models.LeagueRoleAdmin {
+		return current == models.LeagueRoleOwner
+	}
+	return current.Includes(models.LeagueRoleAdmin)
+}
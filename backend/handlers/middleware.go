@@ -12,7 +12,7 @@ import (
 // CORSMiddleware adds CORS headers to allow web frontend access
 func CORSMiddleware(c *gin.Context) {
 	c.Writer.Header().Set("Access-Control-Allow-Origin", "*") // In production, set this to your frontend domain
-	c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+	c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 	c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 	c.Writer.Header().Set("Access-Control-Max-Age", "3600")
 
@@ -59,11 +59,12 @@ func LoggingMiddleware(c *gin.Context) {
 	)
 }
 
-// AuthMiddleware verifies the JWT token and sets the user ID in context
-func AuthMiddleware(c *gin.Context) {
+// AuthMiddleware verifies the access token, refuses it if it has been
+// revoked, and sets the user ID and token claims in context
+func (h *AuthHandler) AuthMiddleware(c *gin.Context) {
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeUnauthorized, "Authorization header required"))
 		c.Abort()
 		return
 	}
@@ -72,21 +73,34 @@ func AuthMiddleware(c *gin.Context) {
 	// Format: "Bearer <token>"
 	parts := strings.Split(authHeader, " ")
 	if len(parts) != 2 || parts[0] != "Bearer" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeUnauthorized, "Invalid authorization header format"))
 		c.Abort()
 		return
 	}
 
-	token := parts[1]
-	userID, err := ValidateToken(token)
+	claims, err := ValidateToken(parts[1])
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeInvalidToken, "Invalid token"))
 		c.Abort()
 		return
 	}
 
-	// Set user ID in context
-	c.Set("userID", userID)
+	revoked, err := h.tokenService.AccessTokenRevoked(claims.ID)
+	if err != nil {
+		log.Printf("AuthMiddleware error - Failed to check revocation: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to verify token"))
+		c.Abort()
+		return
+	}
+	if revoked {
+		c.Error(NewAPIError(http.StatusUnauthorized, CodeInvalidToken, "Token has been revoked"))
+		c.Abort()
+		return
+	}
+
+	// Set user ID and claims in context
+	c.Set("userID", claims.UserID)
+	c.Set("tokenClaims", claims)
 	c.Next()
 }
 
@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+type PlayerHandler struct {
+	db *gorm.DB
+}
+
+func NewPlayerHandler(db *gorm.DB) *PlayerHandler {
+	return &PlayerHandler{db: db}
+}
+
+// CreatePlayer handles adding a player to a league by name
+// @Summary Create a player
+// @Description Add a player to a league by name, for guests and players without an IFPA number
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param request body models.CreatePlayerRequest true "Player details"
+// @Success 201 {object} ListResponse{data=PlayerResponse} "Player created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or league ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/players [post]
+func (h *PlayerHandler) CreatePlayer(c *gin.Context) {
+	league := c.MustGet("league").(*models.League)
+
+	var req models.CreatePlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("CreatePlayer error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	player := models.Player{
+		Name:       req.Name,
+		LeagueID:   league.ID,
+		IFPANumber: req.IFPANumber,
+	}
+	if err := h.db.Create(&player).Error; err != nil {
+		log.Printf("CreatePlayer error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create player"))
+		return
+	}
+
+	log.Printf("CreatePlayer success - Player %s added to league %d", player.Name, league.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"data": player,
+	})
+}
+
+// MergePlayers handles combining a duplicate player into another
+// @Summary Merge two players
+// @Description Move every event attendance, group place, game result, finals result and event score of the source player onto the player in the path, then delete the source player. The surviving player keeps their name and takes the source's IFPA number if they have none. Players who both have results in the same event, or who sit in different groups of the same event, cannot be merged.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param playerID path string true "ID of the player that survives the merge"
+// @Param request body models.MergePlayersRequest true "Player to merge away"
+// @Success 200 {object} ListResponse{data=PlayerResponse} "Players merged successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or player ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Player not found"
+// @Failure 409 {object} ErrorResponse "Both players have results, or are in different groups, in the same event"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/players/{playerID}/merge [post]
+func (h *PlayerHandler) MergePlayers(c *gin.Context) {
+	var req models.MergePlayersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("MergePlayers error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	target := c.MustGet("player").(*models.Player)
+	if req.SourcePlayerID == target.ID {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, "A player cannot be merged into themselves"))
+		return
+	}
+
+	var source models.Player
+	if err := h.db.Where("league_id = ?", target.LeagueID).First(&source, req.SourcePlayerID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.Error(NewAPIError(http.StatusNotFound, CodePlayerNotFound, "Source player not found in this league"))
+			return
+		}
+		log.Printf("MergePlayers error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch player"))
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		return mergePlayers(tx, &source, target)
+	})
+	if err != nil {
+		respondWithError(c, "MergePlayers", "Failed to merge players", err)
+		return
+	}
+
+	log.Printf("MergePlayers success - Player %d merged into player %d", source.ID, target.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": target,
+	})
+}
+
+// UpdatePlayer handles changing a player's details
+// @Summary Update a player
+// @Description Change a player's name or IFPA number, or archive them. Omitted fields are left unchanged. Archived players keep their results but are hidden from the league's player list.
+// @Tags players
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param playerID path string true "Player ID"
+// @Param request body models.UpdatePlayerRequest true "Player changes"
+// @Success 200 {object} ListResponse{data=PlayerResponse} "Player updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or player ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Player not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/players/{playerID} [patch]
+func (h *PlayerHandler) UpdatePlayer(c *gin.Context) {
+	var req models.UpdatePlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("UpdatePlayer error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	player := c.MustGet("player").(*models.Player)
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.IFPANumber != nil && *req.IFPANumber != player.IFPANumber {
+		updates["ifpa_number"] = *req.IFPANumber
+		// Figures fetched for the old number no longer apply
+		updates["ifpa_rank"] = 0
+		updates["ifpa_points"] = 0
+		updates["ifpa_rating"] = 0
+		updates["ifpa_fetched_at"] = nil
+	}
+	if req.IsArchived != nil {
+		updates["is_archived"] = *req.IsArchived
+	}
+
+	if len(updates) > 0 {
+		if err := h.db.Model(player).Updates(updates).Error; err != nil {
+			log.Printf("UpdatePlayer error - Database error: %v", err)
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to update player"))
+			return
+		}
+	}
+
+	log.Printf("UpdatePlayer success - Player %d updated", player.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": player,
+	})
+}
+
+// DeletePlayer handles deleting a player
+// @Summary Delete a player
+// @Description Delete a player and remove them from any events and groups they are in. Players who have recorded results cannot be deleted; archive them instead.
+// @Tags players
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param playerID path string true "Player ID"
+// @Success 204 "Player deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid player ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Player not found"
+// @Failure 409 {object} ErrorResponse "Player has recorded results"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/players/{playerID} [delete]
+func (h *PlayerHandler) DeletePlayer(c *gin.Context) {
+	player := c.MustGet("player").(*models.Player)
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		hasResults, err := playerHasResults(tx, player.ID)
+		if err != nil {
+			return err
+		}
+		if hasResults {
+			return NewAPIError(http.StatusConflict, CodeConflict, "Players with recorded results cannot be deleted; archive them instead")
+		}
+		if err := tx.Exec("DELETE FROM event_players WHERE player_id = ?", player.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM group_players WHERE player_id = ?", player.ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(player).Error
+	})
+	if err != nil {
+		respondWithError(c, "DeletePlayer", "Failed to delete", err)
+		return
+	}
+
+	log.Printf("DeletePlayer success - Player %d deleted", player.ID)
+	c.Status(http.StatusNoContent)
+}
+
+// mergePlayers moves every record of the source player onto the target player
+// and soft deletes the source
+func mergePlayers(tx *gorm.DB, source, target *models.Player) error {
+	sourceEvents, err := resultEventIDs(tx, source.ID)
+	if err != nil {
+		return err
+	}
+	targetEvents, err := resultEventIDs(tx, target.ID)
+	if err != nil {
+		return err
+	}
+	for eventID := range sourceEvents {
+		if targetEvents[eventID] {
+			return NewAPIError(http.StatusConflict, CodeConflict, fmt.Sprintf("Both players have results in event %d", eventID))
+		}
+	}
+
+	// A player can only sit in one group per event, so two players already
+	// placed in different groups of the same event must be regrouped first
+	var splitEvents []uint
+	if err := tx.Model(&models.Group{}).
+		Joins("JOIN group_players ON group_players.group_id = groups.id AND group_players.player_id = ?", source.ID).
+		Where("groups.event_id IN (?)", tx.Model(&models.Group{}).
+			Joins("JOIN group_players ON group_players.group_id = groups.id AND group_players.player_id = ?", target.ID).
+			Where("groups.id NOT IN (SELECT group_id FROM group_players WHERE player_id = ?)", source.ID).
+			Select("groups.event_id")).
+		Pluck("groups.event_id", &splitEvents).Error; err != nil {
+		return err
+	}
+	if len(splitEvents) > 0 {
+		return NewAPIError(http.StatusConflict, CodeConflict, fmt.Sprintf("Both players are in different groups in event %d; regroup the event before merging", splitEvents[0]))
+	}
+
+	// Attendance and group places are join rows keyed by player, so drop the
+	// source's rows wherever the target is already present before moving the rest
+	for _, join := range []struct{ table, key string }{
+		{"event_players", "event_id"},
+		{"group_players", "group_id"},
+	} {
+		if err := tx.Exec(
+			fmt.Sprintf("DELETE FROM %[1]s WHERE player_id = ? AND %[2]s IN (SELECT %[2]s FROM %[1]s WHERE player_id = ?)", join.table, join.key),
+			source.ID, target.ID,
+		).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf("UPDATE %s SET player_id = ? WHERE player_id = ?", join.table), target.ID, source.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, model := range []interface{}{&models.GameResult{}, &models.FinalsMatchPlayer{}, &models.EventScore{}} {
+		if err := tx.Model(model).Where("player_id = ?", source.ID).Update("player_id", target.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	if target.IFPANumber == "" && source.IFPANumber != "" {
+		target.IFPANumber = source.IFPANumber
+		target.IFPARank = source.IFPARank
+		target.IFPAPoints = source.IFPAPoints
+		target.IFPARating = source.IFPARating
+		target.IFPAFetchedAt = source.IFPAFetchedAt
+		if err := tx.Model(target).Select("ifpa_number", "ifpa_rank", "ifpa_points", "ifpa_rating", "ifpa_fetched_at").Updates(target).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Delete(source).Error
+}
+
+// resultEventIDs returns the IDs of every event in which the player has a
+// game result, a finals result or a stored score
+func resultEventIDs(tx *gorm.DB, playerID uint) (map[uint]bool, error) {
+	queries := []*gorm.DB{
+		tx.Model(&models.GameResult{}).
+			Joins("JOIN games ON games.id = game_results.game_id").
+			Where("game_results.player_id = ? AND games.deleted_at IS NULL", playerID).
+			Select("games.event_id"),
+		tx.Model(&models.FinalsMatchPlayer{}).
+			Joins("JOIN finals_matches ON finals_matches.id = finals_match_players.match_id").
+			Joins("JOIN finals ON finals.id = finals_matches.finals_id").
+			Where("finals_match_players.player_id = ?", playerID).
+			Select("finals.event_id"),
+		tx.Model(&models.EventScore{}).
+			Where("player_id = ?", playerID).
+			Select("event_id"),
+	}
+
+	eventIDs := make(map[uint]bool)
+	for _, query := range queries {
+		var ids []uint
+		if err := query.Scan(&ids).Error; err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			eventIDs[id] = true
+		}
+	}
+	return eventIDs, nil
+}
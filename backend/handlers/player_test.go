@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+func TestMergePlayersInDifferentGroupsOfAnEvent(t *testing.T) {
+	db := newTestDB(t, &models.Player{}, &models.Event{}, &models.Group{}, &models.Game{}, &models.GameResult{},
+		&models.Finals{}, &models.FinalsMatch{}, &models.FinalsMatchPlayer{}, &models.EventScore{})
+
+	source := models.Player{Name: "Keith", LeagueID: 1}
+	target := models.Player{Name: "Keith E", LeagueID: 1}
+	db.Create(&source)
+	db.Create(&target)
+	event := models.Event{Name: "Week 1", Date: time.Now(), SeasonID: 1, Players: []models.Player{source, target}}
+	db.Create(&event)
+	db.Create(&models.Group{EventID: event.ID, Number: 1, Players: []models.Player{source}})
+	db.Create(&models.Group{EventID: event.ID, Number: 2, Players: []models.Player{target}})
+
+	err := mergePlayers(db, &source, &target)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusConflict {
+		t.Fatalf("mergePlayers error = %v, want a 409", err)
+	}
+	var groups int64
+	db.Table("group_players").Where("player_id = ?", target.ID).Count(&groups)
+	if groups != 1 {
+		t.Errorf("target is in %d groups, want 1", groups)
+	}
+}
+
+func TestMergePlayersInTheSameGroup(t *testing.T) {
+	db := newTestDB(t, &models.Player{}, &models.Event{}, &models.Group{}, &models.Game{}, &models.GameResult{},
+		&models.Finals{}, &models.FinalsMatch{}, &models.FinalsMatchPlayer{}, &models.EventScore{})
+
+	source := models.Player{Name: "Keith", LeagueID: 1}
+	target := models.Player{Name: "Keith E", LeagueID: 1}
+	db.Create(&source)
+	db.Create(&target)
+	event := models.Event{Name: "Week 1", Date: time.Now(), SeasonID: 1, Players: []models.Player{source, target}}
+	db.Create(&event)
+	db.Create(&models.Group{EventID: event.ID, Number: 1, Players: []models.Player{source, target}})
+
+	if err := mergePlayers(db, &source, &target); err != nil {
+		t.Fatalf("mergePlayers: %v", err)
+	}
+	var groups int64
+	db.Table("group_players").Where("player_id = ?", target.ID).Count(&groups)
+	if groups != 1 {
+		t.Errorf("target is in %d groups, want 1", groups)
+	}
+}
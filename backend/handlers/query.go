@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/services"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+// Pagination describes the page of results returned by a list endpoint
+type Pagination struct {
+	Page       int   `json:"page" example:"1"`
+	Limit      int   `json:"limit" example:"50"`
+	Total      int64 `json:"total" example:"120"`
+	TotalPages int   `json:"totalPages" example:"3"`
+}
+
+// listQuery builds the query behind a list endpoint from its query string.
+// Filters are added one parameter at a time; the first invalid parameter is
+// remembered and reported by Find, so handlers only check for an error once.
+//
+// Every list endpoint accepts page (from 1), limit (up to 100, default 50)
+// and sort, a comma separated list of sort fields each optionally prefixed
+// with "-" for descending order.
+type listQuery struct {
+	c          *gin.Context
+	db         *gorm.DB
+	sortFields map[string]string
+	err        *APIError
+}
+
+// newListQuery starts a list query over db. sortFields maps each sort field a
+// client may name to the column it sorts by.
+func newListQuery(c *gin.Context, db *gorm.DB, sortFields map[string]string) *listQuery {
+	return &listQuery{c: c, db: db, sortFields: sortFields}
+}
+
+// Equal keeps rows whose column equals the parameter, when it is given
+func (q *listQuery) Equal(param, column string) *listQuery {
+	if value, ok := q.c.GetQuery(param); ok && value != "" && q.err == nil {
+		q.db = q.db.Where(column+" = ?", value)
+	}
+	return q
+}
+
+// ID keeps rows whose column equals the numeric ID in the parameter, when it is given
+func (q *listQuery) ID(param, column string) *listQuery {
+	value, ok := q.c.GetQuery(param)
+	if !ok || q.err != nil {
+		return q
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		q.err = invalidField(param, "id", param+" must be a numeric ID")
+		return q
+	}
+	q.db = q.db.Where(column+" = ?", id)
+	return q
+}
+
+// Prefix keeps rows whose column starts with the parameter, ignoring case,
+// when it is given
+func (q *listQuery) Prefix(param, column string) *listQuery {
+	value, ok := q.c.GetQuery(param)
+	if !ok || value == "" || q.err != nil {
+		return q
+	}
+	pattern := services.EscapeLike(strings.ToLower(value)) + "%"
+	q.db = q.db.Where("LOWER("+column+`) LIKE ? ESCAPE '\'`, pattern)
+	return q
+}
+
+// Bool keeps rows whose column matches the true or false parameter, when it is given
+func (q *listQuery) Bool(param, column string) *listQuery {
+	value, ok := q.c.GetQuery(param)
+	if !ok || q.err != nil {
+		return q
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		q.err = invalidField(param, "boolean", param+" must be true or false")
+		return q
+	}
+	q.db = q.db.Where(column+" = ?", b)
+	return q
+}
+
+// TimeRange keeps rows whose column falls on or after the from parameter and
+// on or before the to parameter, each applied when given
+func (q *listQuery) TimeRange(fromParam, toParam, column string) *listQuery {
+	for _, bound := range []struct {
+		param string
+		op    string
+	}{{fromParam, ">="}, {toParam, "<="}} {
+		value, ok := q.c.GetQuery(bound.param)
+		if !ok || q.err != nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			q.err = invalidField(bound.param, "rfc3339", bound.param+" must be an RFC3339 timestamp")
+			return q
+		}
+		q.db = q.db.Where(column+" "+bound.op+" ?", t)
+	}
+	return q
+}
+
+// Find counts the matching rows, then loads the requested page of them into
+// dest in the requested order, loading the named associations as well. Rows
+// with equal sort values are ordered by ID so pages never overlap.
+func (q *listQuery) Find(dest interface{}, preloads ...string) (*Pagination, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	page, limit, err := q.page()
+	if err != nil {
+		return nil, err
+	}
+	order, err := q.order()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err := q.db.Session(&gorm.Session{}).Model(dest).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	query := q.db.Order(order).Offset((page - 1) * limit).Limit(limit)
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+	if err := query.Find(dest).Error; err != nil {
+		return nil, err
+	}
+
+	return &Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+func (q *listQuery) page() (int, int, *APIError) {
+	page, limit := 1, defaultPageLimit
+	if value, ok := q.c.GetQuery("page"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return 0, 0, invalidField("page", "min", "page must be a number of at least 1")
+		}
+		page = n
+	}
+	if value, ok := q.c.GetQuery("limit"); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPageLimit {
+			return 0, 0, invalidField("limit", "range", "limit must be between 1 and "+strconv.Itoa(maxPageLimit))
+		}
+		limit = n
+	}
+	return page, limit, nil
+}
+
+// order turns the sort parameter into an ORDER BY clause. Only the endpoint's
+// sort fields are accepted, so no client input reaches the SQL.
+func (q *listQuery) order() (string, *APIError) {
+	var clauses []string
+	for _, field := range strings.Split(q.c.Query("sort"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+			field = field[1:]
+		}
+		column, ok := q.sortFields[field]
+		if !ok {
+			return "", invalidField("sort", "oneof", "sort must be one of: "+strings.Join(q.sortNames(), ", "))
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+	return strings.Join(append(clauses, "id ASC"), ", "), nil
+}
+
+func (q *listQuery) sortNames() []string {
+	names := make([]string, 0, len(q.sortFields))
+	for name := range q.sortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
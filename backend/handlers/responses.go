@@ -1,6 +1,16 @@
 package handlers
 
-import "backend/models"
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/models"
+	"backend/services"
+)
 
 // UserResponse represents the user data in API responses
 type UserResponse struct {
@@ -9,8 +19,15 @@ type UserResponse struct {
 
 // AuthResponse represents the authentication response
 type AuthResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	User UserResponse `json:"user"`
+	TokenResponse
+}
+
+// TokenResponse represents a newly issued access and refresh token pair
+type TokenResponse struct {
+	Token        string    `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string    `json:"refreshToken" example:"dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"`
+	ExpiresAt    time.Time `json:"expiresAt" example:"2024-01-01T00:15:00Z"`
 }
 
 // LeagueResponse represents the league data in API responses
@@ -18,27 +35,103 @@ type LeagueResponse struct {
 	models.SwaggerLeague
 }
 
+// LeagueMachineResponse represents a machine in a league's bank in API responses
+type LeagueMachineResponse struct {
+	models.LeagueMachine
+}
+
+// MemberResponse represents a league staff member in API responses
+type MemberResponse struct {
+	models.LeagueMembership
+}
+
 // SeasonResponse represents the season data in API responses
 type SeasonResponse struct {
 	models.SwaggerSeason
 }
 
+// StandingResponse represents a player's row in the season standings
+type StandingResponse struct {
+	services.StandingRow
+}
+
 // EventResponse represents the event data in API responses
 type EventResponse struct {
 	models.Event
 }
 
+// EventDetailResponse represents a single event along with the points earned by each player
+type EventDetailResponse struct {
+	models.Event
+	Points []services.PlayerEventPoints `json:"points"`
+}
+
+// SeedingResponse represents the seeding order for an event
+type SeedingResponse struct {
+	services.Seeding
+}
+
 // PlayerResponse represents the player data in API responses
 type PlayerResponse struct {
 	models.Player
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error" example:"Invalid request body"`
+// IFPAImportResponse is the outcome for one IFPA number in a bulk add. Player
+// is set when the player was added or was already in the league.
+type IFPAImportResponse struct {
+	IFPANumber int                     `json:"ifpaNumber"`
+	Status     models.IFPAImportStatus `json:"status"`
+	Player     *models.Player          `json:"player,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+// CheckInResponse represents a player checked in to an event and the group
+// they were placed in, if groups have been generated and one had room
+type CheckInResponse struct {
+	Player  models.Player `json:"player"`
+	GroupID *uint         `json:"groupID"`
+}
+
+// GameResponse represents the game data in API responses
+type GameResponse struct {
+	models.Game
+}
+
+// GroupResponse represents the group data in API responses
+type GroupResponse struct {
+	models.Group
+}
+
+// ScheduledGameResponse represents a scheduled game in API responses
+type ScheduledGameResponse struct {
+	models.ScheduledGame
+}
+
+// FinalsResponse represents the finals bracket and current ranking in API responses
+type FinalsResponse struct {
+	models.Finals
+	Ranking []services.FinalsRankingRow `json:"ranking"`
+}
+
+// respondWithError passes on an APIError returned from a transaction,
+// or logs any other error and responds with 500 and the fallback message
+func respondWithError(c *gin.Context, action, fallback string, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		c.Error(apiErr)
+		return
+	}
+	log.Printf("%s error - Database error: %v", action, err)
+	c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, fallback))
 }
 
 // ListResponse represents a list response with data
 type ListResponse struct {
 	Data interface{} `json:"data"`
 }
+
+// PagedResponse represents one page of a list response
+type PagedResponse struct {
+	Data       interface{} `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
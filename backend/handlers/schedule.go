@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"backend/models"
+	"backend/services"
+)
+
+type ScheduleHandler struct {
+	db *gorm.DB
+}
+
+func NewScheduleHandler(db *gorm.DB) *ScheduleHandler {
+	return &ScheduleHandler{db: db}
+}
+
+// GenerateSchedule handles building the machine rotation for an event
+// @Summary Generate the schedule for an event
+// @Description Assign each group a different machine in every round, replacing any existing schedule. Groups must be generated first, and only the event's machines that are in order in the league's bank are used.
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Param request body models.GenerateScheduleRequest true "Schedule options"
+// @Success 201 {object} ListResponse{data=[]ScheduledGameResponse} "Schedule generated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body, event ID, or not enough machines"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 409 {object} ErrorResponse "Event has already started"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/schedule [post]
+func (h *ScheduleHandler) GenerateSchedule(c *gin.Context) {
+	var req models.GenerateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("GenerateSchedule error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
+		return
+	}
+
+	event, ok := loadEditableEvent(c, h.db)
+	if !ok {
+		return
+	}
+
+	var groups []models.Group
+	if err := h.db.Where("event_id = ?", event.ID).Order("number").Find(&groups).Error; err != nil {
+		log.Printf("GenerateSchedule error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch groups"))
+		return
+	}
+
+	league := c.MustGet("league").(*models.League)
+	machines, err := activeEventMachines(h.db, event, league.ID)
+	if err != nil {
+		log.Printf("GenerateSchedule error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch machines"))
+		return
+	}
+
+	schedule, err := services.BuildSchedule(groups, machines, req.GamesPerGroup, event.Seed)
+	if err != nil {
+		c.Error(NewAPIError(http.StatusBadRequest, CodeBadRequest, err.Error()))
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", event.ID).Delete(&models.ScheduledGame{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&schedule).Error
+	})
+	if err != nil {
+		log.Printf("GenerateSchedule error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to save schedule"))
+		return
+	}
+
+	log.Printf("GenerateSchedule success - Scheduled %d games for event %d", len(schedule), event.ID)
+	h.respondWithSchedule(c, http.StatusCreated, event.ID)
+}
+
+// GetSchedule handles getting the machine rotation for an event
+// @Summary Get the schedule for an event
+// @Description Get the machine each group plays in each round, and which of those games have been recorded
+// @Tags schedule
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} ListResponse{data=[]ScheduledGameResponse} "Event schedule"
+// @Failure 400 {object} ErrorResponse "Invalid event ID"
+// @Failure 404 {object} ErrorResponse "Event not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/events/{eventID}/schedule [get]
+func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
+	event := c.MustGet("event").(*models.Event)
+	h.respondWithSchedule(c, http.StatusOK, event.ID)
+}
+
+func (h *ScheduleHandler) respondWithSchedule(c *gin.Context, status int, eventID uint) {
+	var schedule []models.ScheduledGame
+	// Groups are created in number order, so ordering by group ID keeps each round in group order
+	if err := h.db.Where("event_id = ?", eventID).
+		Preload("Group.Players").
+		Preload("Machine").
+		Order("round, group_id").
+		Find(&schedule).Error; err != nil {
+		log.Printf("GetSchedule error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch schedule"))
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"data": schedule,
+	})
+}
@@ -1,72 +1,77 @@
 package handlers
 
 import (
-	"errors"
 	"log"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
 	"backend/models"
+	"backend/services"
 )
 
 type SeasonHandler struct {
-	db *gorm.DB
+	db             *gorm.DB
+	scoringService *services.ScoringService
 }
 
-func NewSeasonHandler(db *gorm.DB) *SeasonHandler {
-	return &SeasonHandler{db: db}
+func NewSeasonHandler(db *gorm.DB, scoringService *services.ScoringService) *SeasonHandler {
+	return &SeasonHandler{
+		db:             db,
+		scoringService: scoringService,
+	}
 }
 
 // CreateSeason handles season creation
 // @Summary Create a new season
-// @Description Create a new season for a specific league
+// @Description Create a new season for a specific league. Counting games must be positive. The optional start and end dates limit when the season's events can be held.
 // @Tags seasons
 // @Accept json
 // @Produce json
 // @Security Bearer
 // @Param leagueID path string true "League ID"
-// @Param request body object true "Season details" {"name": "string"}
+// @Param request body models.CreateSeasonRequest true "Season details"
 // @Success 201 {object} ListResponse{data=SeasonResponse} "Season created successfully"
 // @Failure 400 {object} ErrorResponse "Invalid request body"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "League not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /leagues/{leagueID}/seasons [post]
 func (h *SeasonHandler) CreateSeason(c *gin.Context) {
-	leagueID := c.Param("leagueID")
-	if leagueID == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "League ID is required"})
-		return
-	}
+	league := c.MustGet("league").(*models.League)
 
-	// Convert leagueID to uint
-	leagueIDUint, err := strconv.ParseUint(leagueID, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid league ID"})
+	var req models.CreateSeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(newValidationError(err))
 		return
 	}
 
-	var req models.CreateSeasonRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	// Group sizes missing from the distribution fall back to the default at scoring time
+	if req.PointDistribution == nil {
+		req.PointDistribution = make(models.PointDistributionMap)
+	}
+	if err := services.ValidatePointDistribution(req.PointDistribution); err != nil {
+		c.Error(invalidField("pointDistribution", "distribution", err.Error()))
 		return
 	}
 
 	season := models.Season{
 		Name:              req.Name,
 		DateCreated:       time.Now(),
-		LeagueID:          uint(leagueIDUint),
+		LeagueID:          league.ID,
 		CountingGames:     req.CountingGames,
 		EventCount:        0,
 		HasFinals:         req.HasFinals,
-		PointDistribution: make(map[string][]float64),
+		PointDistribution: req.PointDistribution,
+		StartDate:         parseRequestDate(req.StartDate),
+		EndDate:           parseRequestDate(req.EndDate),
 	}
 
 	if err := h.db.Create(&season).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to create season"})
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to create season"))
 		return
 	}
 
@@ -75,77 +80,263 @@ func (h *SeasonHandler) CreateSeason(c *gin.Context) {
 
 // ListSeasons handles listing all seasons for a league
 // @Summary List seasons for a league
-// @Description Get a list of all seasons for a specific league
+// @Description Get a page of the seasons in a specific league, optionally filtered by name prefix or whether they have finals
 // @Tags seasons
 // @Produce json
 // @Param leagueID path string true "League ID"
-// @Success 200 {object} ListResponse{data=[]SeasonResponse} "List of seasons"
-// @Failure 400 {object} ErrorResponse "Invalid league ID"
+// @Param name query string false "Only seasons whose name starts with this, ignoring case"
+// @Param hasFinals query bool false "Only seasons with, or without, finals"
+// @Param sort query string false "Comma separated sort fields, each optionally prefixed with - for descending: name, dateCreated, startDate"
+// @Param page query int false "Page number, from 1" default(1)
+// @Param limit query int false "Seasons per page, at most 100" default(50)
+// @Success 200 {object} PagedResponse{data=[]SeasonResponse} "Page of seasons"
+// @Failure 400 {object} ErrorResponse "Invalid league ID, filter, sort or page"
+// @Failure 404 {object} ErrorResponse "League not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /leagues/{leagueID}/seasons [get]
 func (h *SeasonHandler) ListSeasons(c *gin.Context) {
-	leagueIDStr := c.Param("leagueID")
-	if leagueIDStr == "" {
-		log.Printf("ListSeasons error - No league ID provided")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "League ID is required"})
-		return
-	}
-
-	// Convert leagueID to uint
-	leagueID, err := strconv.ParseUint(leagueIDStr, 10, 32)
-	if err != nil {
-		log.Printf("ListSeasons error - Invalid league ID: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid league ID"})
-		return
-	}
+	league := c.MustGet("league").(*models.League)
 
 	var seasons []models.Season
-	if err := h.db.Where("league_id = ?", leagueID).Preload("League").Find(&seasons).Error; err != nil {
-		log.Printf("ListSeasons error - Database error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch seasons"})
+	pagination, err := newListQuery(c, h.db.Where("league_id = ?", league.ID), seasonSortFields).
+		Prefix("name", "name").
+		Bool("hasFinals", "has_finals").
+		Find(&seasons, "League")
+	if err != nil {
+		respondWithError(c, "ListSeasons", "Failed to fetch seasons", err)
 		return
 	}
 
-	log.Printf("ListSeasons success - Retrieved %d seasons for league %d", len(seasons), leagueID)
+	log.Printf("ListSeasons success - Retrieved %d of %d seasons for league %d", len(seasons), pagination.Total, league.ID)
 	c.JSON(http.StatusOK, gin.H{
-		"data": seasons,
+		"data":       seasons,
+		"pagination": pagination,
 	})
 }
 
+var seasonSortFields = map[string]string{
+	"name":        "name",
+	"dateCreated": "date_created",
+	"startDate":   "start_date",
+}
+
 // GetSeason handles getting a season by ID
 // @Summary Get a season by ID
 // @Description Get detailed information about a specific season
 // @Tags seasons
 // @Produce json
+// @Param leagueID path string true "League ID"
 // @Param seasonID path string true "Season ID"
 // @Success 200 {object} SeasonResponse "Season details"
 // @Failure 400 {object} ErrorResponse "Invalid season ID"
 // @Failure 404 {object} ErrorResponse "Season not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /seasons/{seasonID} [get]
+// @Router /leagues/{leagueID}/seasons/{seasonID} [get]
 func (h *SeasonHandler) GetSeason(c *gin.Context) {
-	seasonIDStr := c.Param("seasonID")
-	if seasonIDStr == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Season ID is required"})
+	season := *c.MustGet("season").(*models.Season)
+	season.League = *c.MustGet("league").(*models.League)
+
+	c.JSON(http.StatusOK, season)
+}
+
+// GetStandings handles getting the standings for a season
+// @Summary Get season standings
+// @Description Get ranked player standings for a season from its completed events, counting each player's best CountingGames event results. Ties are broken by event wins, then best finish, then registration order.
+// @Tags seasons
+// @Produce json
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Success 200 {object} ListResponse{data=[]StandingResponse} "Season standings"
+// @Failure 400 {object} ErrorResponse "Invalid season ID"
+// @Failure 404 {object} ErrorResponse "Season not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID}/standings [get]
+func (h *SeasonHandler) GetStandings(c *gin.Context) {
+	season := c.MustGet("season").(*models.Season)
+
+	standings, err := h.scoringService.SeasonStandings(season.ID)
+	if err != nil {
+		log.Printf("GetStandings error - Failed to compute standings: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to compute standings"))
 		return
 	}
 
-	// Convert seasonID to uint
-	seasonID, err := strconv.ParseUint(seasonIDStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid season ID"})
+	log.Printf("GetStandings success - Computed standings for %d players in season %d", len(standings), season.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": standings,
+	})
+}
+
+// UpdateSeason handles changing a season's configuration
+// @Summary Update a season
+// @Description Change a season's name, counting games, finals, point distribution or start and end dates. Omitted fields are left unchanged. The point distribution cannot change once an event has been completed, since completed events keep the points they were scored with, and the dates cannot move past any of the season's events.
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Param request body models.UpdateSeasonRequest true "Season changes"
+// @Success 200 {object} ListResponse{data=SeasonResponse} "Season updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request body or season ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Season not found"
+// @Failure 409 {object} ErrorResponse "Change conflicts with completed events, finals or event dates"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID} [patch]
+func (h *SeasonHandler) UpdateSeason(c *gin.Context) {
+	seasonID := c.MustGet("season").(*models.Season).ID
+
+	var req models.UpdateSeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("UpdateSeason error - Invalid request body: %v", err)
+		c.Error(newValidationError(err))
 		return
 	}
 
-	var season models.Season
-	if err := h.db.Preload("League").First(&season, "id = ?", seasonID).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: "Season not found"})
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.CountingGames != nil {
+		updates["counting_games"] = *req.CountingGames
+	}
+
+	if req.HasFinals != nil {
+		if !*req.HasFinals {
+			var finalsCount int64
+			if err := h.db.Model(&models.Finals{}).
+				Joins("JOIN events ON events.id = finals.event_id").
+				Where("events.season_id = ? AND events.deleted_at IS NULL", seasonID).
+				Count(&finalsCount).Error; err != nil {
+				log.Printf("UpdateSeason error - Database error: %v", err)
+				c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to check finals"))
+				return
+			}
+			if finalsCount > 0 {
+				c.Error(NewAPIError(http.StatusConflict, CodeFinalsStarted, "Finals have already started for this season"))
+				return
+			}
+		}
+		updates["has_finals"] = *req.HasFinals
+	}
+
+	if req.PointDistribution != nil {
+		if err := services.ValidatePointDistribution(req.PointDistribution); err != nil {
+			c.Error(invalidField("pointDistribution", "distribution", err.Error()))
+			return
+		}
+		var completedCount int64
+		if err := h.db.Model(&models.Event{}).Where("season_id = ? AND is_complete = ?", seasonID, true).Count(&completedCount).Error; err != nil {
+			log.Printf("UpdateSeason error - Database error: %v", err)
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to check events"))
+			return
+		}
+		if completedCount > 0 {
+			c.Error(NewAPIError(http.StatusConflict, CodeConflict, "The point distribution cannot change once an event has been completed"))
+			return
+		}
+		updates["point_distribution"] = req.PointDistribution
+	}
+
+	if req.StartDate != nil || req.EndDate != nil {
+		window := *c.MustGet("season").(*models.Season)
+		if req.StartDate != nil {
+			window.StartDate = parseRequestDate(req.StartDate)
+		}
+		if req.EndDate != nil {
+			window.EndDate = parseRequestDate(req.EndDate)
+		}
+		if window.StartDate != nil && window.EndDate != nil && window.EndDate.Before(*window.StartDate) {
+			c.Error(invalidField("endDate", "gtefield", "endDate must not be before startDate"))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to fetch season"})
+
+		outside := h.db.Model(&models.Event{}).Where("season_id = ?", seasonID)
+		switch {
+		case window.StartDate != nil && window.EndDate != nil:
+			outside = outside.Where("date < ? OR date > ?", *window.StartDate, *window.EndDate)
+		case window.StartDate != nil:
+			outside = outside.Where("date < ?", *window.StartDate)
+		default:
+			outside = outside.Where("date > ?", *window.EndDate)
+		}
+		var outsideCount int64
+		if err := outside.Count(&outsideCount).Error; err != nil {
+			log.Printf("UpdateSeason error - Database error: %v", err)
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to check events"))
+			return
+		}
+		if outsideCount > 0 {
+			c.Error(NewAPIError(http.StatusConflict, CodeConflict, "The season has events outside the new start and end dates"))
+			return
+		}
+		updates["start_date"] = window.StartDate
+		updates["end_date"] = window.EndDate
+	}
+
+	if len(updates) > 0 {
+		if err := h.db.Model(&models.Season{}).Where("id = ?", seasonID).Updates(updates).Error; err != nil {
+			log.Printf("UpdateSeason error - Database error: %v", err)
+			c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to update season"))
+			return
+		}
+	}
+
+	var season models.Season
+	if err := h.db.Preload("League").First(&season, seasonID).Error; err != nil {
+		log.Printf("UpdateSeason error - Database error: %v", err)
+		c.Error(NewAPIError(http.StatusInternalServerError, CodeInternal, "Failed to fetch season"))
 		return
 	}
 
-	c.JSON(http.StatusOK, season)
+	log.Printf("UpdateSeason success - Season %d updated", season.ID)
+	c.JSON(http.StatusOK, gin.H{
+		"data": season,
+	})
+}
+
+// DeleteSeason handles deleting a season
+// @Summary Delete a season
+// @Description Delete a season along with its events. Seasons with any recorded results cannot be deleted.
+// @Tags seasons
+// @Produce json
+// @Security Bearer
+// @Param leagueID path string true "League ID"
+// @Param seasonID path string true "Season ID"
+// @Success 204 "Season deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid season ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Insufficient league role"
+// @Failure 404 {object} ErrorResponse "Season not found"
+// @Failure 409 {object} ErrorResponse "Season has recorded results"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /leagues/{leagueID}/seasons/{seasonID} [delete]
+func (h *SeasonHandler) DeleteSeason(c *gin.Context) {
+	seasonID := c.MustGet("season").(*models.Season).ID
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		return deleteSeasons(tx, []uint{seasonID})
+	})
+	if err != nil {
+		respondWithError(c, "DeleteSeason", "Failed to delete", err)
+		return
+	}
+
+	log.Printf("DeleteSeason success - Season %d deleted", seasonID)
+	c.Status(http.StatusNoContent)
+}
+
+// parseRequestDate parses an optional timestamp that the rfc3339 binding
+// rule has already checked
+func parseRequestDate(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	date, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &date
 }
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"backend/models"
+)
+
+// RegisterValidation configures the binding validator. Field names in
+// validation errors come from the json tag, so they match what clients send.
+// It also adds the rules request models use beyond the validator's built-in
+// ones:
+//
+//   - enum: the value is one of the field type's models.Enum values
+//   - rfc3339: the string is an RFC3339 timestamp
+func RegisterValidation() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	validate.RegisterValidation("enum", validateEnum)
+	validate.RegisterValidation("rfc3339", validateRFC3339)
+	validate.RegisterStructValidation(validateCreateSeason, models.CreateSeasonRequest{})
+	validate.RegisterStructValidation(validateUpdateSeason, models.UpdateSeasonRequest{})
+}
+
+func validateEnum(fl validator.FieldLevel) bool {
+	enum, ok := fl.Field().Interface().(models.Enum)
+	return ok && enum.Valid()
+}
+
+func validateRFC3339(fl validator.FieldLevel) bool {
+	_, err := time.Parse(time.RFC3339, fl.Field().String())
+	return err == nil
+}
+
+func validateCreateSeason(sl validator.StructLevel) {
+	req := sl.Current().Interface().(models.CreateSeasonRequest)
+	validateSeasonWindow(sl, req.StartDate, req.EndDate)
+}
+
+func validateUpdateSeason(sl validator.StructLevel) {
+	req := sl.Current().Interface().(models.UpdateSeasonRequest)
+	validateSeasonWindow(sl, req.StartDate, req.EndDate)
+}
+
+// validateSeasonWindow rejects an end date before the start date when a
+// request gives both
+func validateSeasonWindow(sl validator.StructLevel, startDate, endDate *string) {
+	if startDate == nil || endDate == nil {
+		return
+	}
+	start, startErr := time.Parse(time.RFC3339, *startDate)
+	end, endErr := time.Parse(time.RFC3339, *endDate)
+	if startErr != nil || endErr != nil {
+		// Already reported by the rfc3339 rule
+		return
+	}
+	if end.Before(start) {
+		sl.ReportError(*endDate, "endDate", "EndDate", "gtefield", "startDate")
+	}
+}
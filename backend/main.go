@@ -2,7 +2,10 @@ package main
 
 import (
 	"log"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	_ "backend/docs"
 	"backend/services"
@@ -31,7 +34,7 @@ import (
 // @license.url   http://www.apache.org/licenses/LICENSE-2.0.html
 
 // @host      localhost:8080
-// @BasePath  /api
+// @BasePath  /api/v1
 
 // @securityDefinitions.apikey Bearer
 // @in header
@@ -42,6 +45,9 @@ func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Printf("Warning: .env file not found")
 	}
+	if err := handlers.LoadJWTConfig(); err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
 
 	// Initialize database
 	db, err := gorm.Open(sqlite.Open("pinball.db"), &gorm.Config{})
@@ -53,57 +59,86 @@ func main() {
 	if err := db.AutoMigrate(
 		&models.User{},
 		&models.League{},
+		&models.LeagueMembership{},
+		&models.LeagueMachine{},
 		&models.Season{},
 		&models.Machine{},
 		&models.Player{},
 		&models.Event{},
+		&models.EventScore{},
+		&models.EventReopening{},
+		&models.Group{},
+		&models.Game{},
+		&models.ScheduledGame{},
+		&models.Finals{},
+		&models.FinalsMatch{},
+		&models.FinalsMatchPlayer{},
+		&models.GameResult{},
+		&models.RefreshToken{},
+		&models.RevokedAccessToken{},
 	); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
 	// Initialize services
-	opdbService := services.NewOPDBService(db)
-	ifpaService := services.NewIFPAService()
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	opdbCacheTTL := 24 * time.Hour
+	if hours := os.Getenv("OPDB_CACHE_TTL_HOURS"); hours != "" {
+		parsed, err := strconv.Atoi(hours)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("OPDB_CACHE_TTL_HOURS must be a positive integer")
+		}
+		opdbCacheTTL = time.Duration(parsed) * time.Hour
+	}
+	opdbService := services.NewOPDBService(db, services.OPDBConfig{
+		APIToken:   os.Getenv("OPDB_API_TOKEN"),
+		BaseURL:    os.Getenv("OPDB_BASE_URL"),
+		HTTPClient: httpClient,
+		CacheTTL:   opdbCacheTTL,
+	})
+	ifpaService := services.NewIFPAService(services.IFPAConfig{
+		APIKey:     os.Getenv("IFPA_API_KEY"),
+		BaseURL:    os.Getenv("IFPA_BASE_URL"),
+		HTTPClient: httpClient,
+	})
+	if !ifpaService.Enabled() {
+		log.Printf("Warning: IFPA_API_KEY not set, IFPA features are disabled")
+	}
+	scoringService := services.NewScoringService(db)
+	seedingService := services.NewSeedingService(db, scoringService)
+	ifpaSyncService := services.NewIFPASyncService(db, ifpaService, services.IFPASyncConfig{})
+	tokenService := services.NewTokenService(db, services.TokenConfig{RefreshTTL: handlers.RefreshTokenExpiration})
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(db)
-	leagueHandler := handlers.NewLeagueHandler(db, ifpaService)
-	seasonHandler := handlers.NewSeasonHandler(db)
-	eventHandler := handlers.NewEventHandler(db)
-	machineHandler := handlers.NewMachineHandler(opdbService)
+	h := routeHandlers{
+		auth:        handlers.NewAuthHandler(db, tokenService),
+		league:      handlers.NewLeagueHandler(db, ifpaService, ifpaSyncService),
+		season:      handlers.NewSeasonHandler(db, scoringService),
+		event:       handlers.NewEventHandler(db, scoringService, seedingService),
+		group:       handlers.NewGroupHandler(db, seedingService),
+		schedule:    handlers.NewScheduleHandler(db),
+		game:        handlers.NewGameHandler(db),
+		finals:      handlers.NewFinalsHandler(db, scoringService),
+		machine:     handlers.NewMachineHandler(opdbService),
+		member:      handlers.NewMemberHandler(db),
+		player:      handlers.NewPlayerHandler(db),
+		attendance:  handlers.NewAttendanceHandler(db),
+		machineBank: handlers.NewMachineBankHandler(db, opdbService),
+		authorizer:  handlers.NewAuthorizer(db),
+		loader:      handlers.NewResourceLoader(db),
+	}
 
 	// Initialize router
+	handlers.RegisterValidation()
 	router := gin.Default()
 
 	// Add middleware
 	router.Use(handlers.CORSMiddleware)
 	router.Use(handlers.LoggingMiddleware)
+	router.Use(handlers.ErrorHandler)
 
-	// Public routes
-	router.POST("/api/auth/signup", authHandler.Signup)
-	router.POST("/api/auth/login", authHandler.Login)
-	router.GET("/api/leagues", leagueHandler.ListLeagues)
-	router.GET("/api/leagues/:leagueID", leagueHandler.GetLeague)
-	router.GET("/api/leagues/:leagueID/seasons", seasonHandler.ListSeasons)
-	router.GET("/api/leagues/:leagueID/players", leagueHandler.ListPlayers)
-	router.GET("/api/seasons/:seasonID", seasonHandler.GetSeason)
-	router.GET("/api/seasons/:seasonID/events", eventHandler.ListEvents)
-	router.GET("/api/events/:eventID", eventHandler.GetEvent)
-	router.GET("/api/machines/:opdb_id", machineHandler.GetMachine)
-
-	// Protected routes
-	protected := router.Group("/api")
-	protected.Use(handlers.AuthMiddleware)
-	{
-		protected.GET("/auth/me", authHandler.GetCurrentUser)
-		// League routes
-		protected.POST("/leagues/create", leagueHandler.CreateLeague)
-		protected.POST("/leagues/:leagueID/add_players_by_ifpa", leagueHandler.AddPlayersByIFPA)
-		// Season routes
-		protected.POST("/leagues/:leagueID/seasons/create", seasonHandler.CreateSeason)
-		// Event routes
-		protected.POST("/seasons/:seasonID/events/create", eventHandler.CreateEvent)
-	}
+	registerRoutes(router.Group("/api/v1"), h)
+	registerLegacyRoutes(router.Group("/api"), h)
 
 	// Swagger documentation endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a server-side record of a refresh token. Only a hash of the
+// token is stored. Each refresh replaces the token with a new one in the same
+// family, so a token presented after it has been replaced shows the family has
+// leaked and the whole family is revoked.
+type RefreshToken struct {
+	gorm.Model `swaggerignore:"true"`
+	UserID     uint       `json:"userID" gorm:"not null;index"`
+	FamilyID   string     `json:"familyID" gorm:"not null;index"`
+	TokenHash  string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revokedAt"` // Set when the token is used, logged out or revoked with its family
+}
+
+// RevokedAccessToken lists an access token that must be refused before it
+// expires, by its jti claim. Rows are only needed until ExpiresAt, after which
+// the token is refused anyway.
+type RevokedAccessToken struct {
+	JTI       string    `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+// RefreshRequest exchanges a refresh token for a new access token
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// LogoutRequest ends a session. The refresh token is optional; when given,
+// it and every token issued from the same login are revoked.
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// Enum is implemented by string types that only allow a fixed set of values.
+// Request fields of these types are checked with the "enum" binding tag.
+type Enum interface {
+	Values() []string
+	Valid() bool
+}
+
+func isEnumValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -8,16 +8,44 @@ import (
 
 type Event struct {
 	gorm.Model      `swaggerignore:"true"`
-	Name            string        `json:"name" gorm:"not null"`
-	Date            time.Time     `json:"date" gorm:"not null"`
-	Players         []Player      `json:"players" gorm:"many2many:event_players;"`
-	Machines        []Machine     `json:"machines" gorm:"many2many:event_machines;"`
-	SeasonID        uint          `json:"seasonID" gorm:"not null"`
-	Season          Season        `json:"season" gorm:"foreignKey:SeasonID"`
-	IsFinals        bool          `json:"isFinals" gorm:"not null"`
-	IsComplete      bool          `json:"isComplete" gorm:"not null"`
-	HasWinnersGroup bool          `json:"hasWinnersGroup" gorm:"not null"`
-	CompletedAt     time.Time     `json:"completedAt" gorm:""`
-	SeedingMethod   SeedingMethod `json:"seedingMethod" gorm:"type:string;default:'AVERAGE'"`
-	GroupOrdering   GroupOrdering `json:"groupOrdering" gorm:"type:string;default:'SEEDED'"`
+	Name            string           `json:"name" gorm:"not null"`
+	Date            time.Time        `json:"date" gorm:"not null"`
+	Players         []Player         `json:"players" gorm:"many2many:event_players;"`
+	Machines        []Machine        `json:"machines" gorm:"many2many:event_machines;"`
+	SeasonID        uint             `json:"seasonID" gorm:"not null"`
+	Season          Season           `json:"season" gorm:"foreignKey:SeasonID"`
+	IsFinals        bool             `json:"isFinals" gorm:"not null"`
+	IsComplete      bool             `json:"isComplete" gorm:"not null"`
+	HasWinnersGroup bool             `json:"hasWinnersGroup" gorm:"not null"`
+	CompletedAt     time.Time        `json:"completedAt" gorm:""`
+	SeedingMethod   SeedingMethod    `json:"seedingMethod" gorm:"type:string;default:'AVERAGE'"`
+	GroupOrdering   GroupOrdering    `json:"groupOrdering" gorm:"type:string;default:'SEEDED'"`
+	Seed            int64            `json:"seed" gorm:"not null;default:0"` // Drives every random draw for the event so it can be reproduced
+	Reopenings      []EventReopening `json:"reopenings,omitempty" gorm:"foreignKey:EventID"`
+}
+
+// CreateEventRequest creates an event in a season. The seeding method and
+// group ordering fall back to their defaults when omitted.
+type CreateEventRequest struct {
+	Name            string        `json:"name" binding:"required"`
+	Date            string        `json:"date" binding:"required,rfc3339" example:"2024-03-01T19:00:00Z"`
+	IsFinals        bool          `json:"isFinals"`
+	HasWinnersGroup bool          `json:"hasWinnersGroup"`
+	SeedingMethod   SeedingMethod `json:"seedingMethod" binding:"omitempty,enum" enums:"AVERAGE,RANK,RANDOM,IFPA_RANK"`
+	GroupOrdering   GroupOrdering `json:"groupOrdering" binding:"omitempty,enum" enums:"RANDOM,SEEDED"`
+	Seed            *int64        `json:"seed"`
+}
+
+// UpdateEventRequest changes an event's details. Omitted fields are left unchanged.
+type UpdateEventRequest struct {
+	Name            *string        `json:"name" binding:"omitempty,min=1"`
+	Date            *string        `json:"date" binding:"omitempty,rfc3339"`
+	HasWinnersGroup *bool          `json:"hasWinnersGroup"`
+	SeedingMethod   *SeedingMethod `json:"seedingMethod" binding:"omitempty,enum" enums:"AVERAGE,RANK,RANDOM,IFPA_RANK"`
+	GroupOrdering   *GroupOrdering `json:"groupOrdering" binding:"omitempty,enum" enums:"RANDOM,SEEDED"`
+}
+
+// CheckInRequest checks several players in to an event at once
+type CheckInRequest struct {
+	PlayerIDs []uint `json:"playerIDs" binding:"required,min=1"`
 }
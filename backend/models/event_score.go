@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EventScore is a player's final points and place in an event, stored when the event is completed
+type EventScore struct {
+	gorm.Model  `swaggerignore:"true"`
+	EventID     uint    `json:"eventID" gorm:"not null;index"`
+	PlayerID    uint    `json:"playerID" gorm:"not null;index"`
+	Player      Player  `json:"player" gorm:"foreignKey:PlayerID"`
+	Points      float64 `json:"points" gorm:"not null"`
+	Place       int     `json:"place" gorm:"not null"`
+	GamesPlayed int     `json:"gamesPlayed" gorm:"not null"`
+}
+
+// EventReopening records who reopened a completed event for corrections, and why
+type EventReopening struct {
+	gorm.Model `swaggerignore:"true"`
+	EventID    uint      `json:"eventID" gorm:"not null;index"`
+	UserID     uint      `json:"userID" gorm:"not null"`
+	User       User      `json:"user" gorm:"foreignKey:UserID"`
+	Reason     string    `json:"reason" gorm:"not null"`
+	ReopenedAt time.Time `json:"reopenedAt" gorm:"not null"`
+}
+
+type ReopenEventRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
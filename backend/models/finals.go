@@ -0,0 +1,60 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+type FinalsFormat string
+
+const (
+	// FinalsFormatPAPA plays four-player groups with the top two in each group advancing
+	FinalsFormatPAPA FinalsFormat = "PAPA"
+	// FinalsFormatHeadToHead plays a single-elimination bracket of one-on-one matches
+	FinalsFormatHeadToHead FinalsFormat = "HEAD_TO_HEAD"
+)
+
+// Finals is the playoff bracket run during a finals event
+type Finals struct {
+	gorm.Model     `swaggerignore:"true"`
+	EventID        uint          `json:"eventID" gorm:"not null;uniqueIndex"`
+	Format         FinalsFormat  `json:"format" gorm:"type:string;not null"`
+	QualifierCount int           `json:"qualifierCount" gorm:"not null"`
+	CurrentRound   int           `json:"currentRound" gorm:"not null"`
+	IsComplete     bool          `json:"isComplete" gorm:"not null"`
+	Matches        []FinalsMatch `json:"matches" gorm:"foreignKey:FinalsID"`
+}
+
+// FinalsMatch is one group or head-to-head match within a round of the finals
+type FinalsMatch struct {
+	gorm.Model `swaggerignore:"true"`
+	FinalsID   uint                `json:"finalsID" gorm:"not null;index"`
+	Round      int                 `json:"round" gorm:"not null"`
+	Number     int                 `json:"number" gorm:"not null"`
+	IsComplete bool                `json:"isComplete" gorm:"not null"`
+	Players    []FinalsMatchPlayer `json:"players" gorm:"foreignKey:MatchID"`
+}
+
+// FinalsMatchPlayer is a player's entry in a finals match. Seed is the
+// player's qualifying position and Position is set once the match is played.
+type FinalsMatchPlayer struct {
+	gorm.Model `swaggerignore:"true"`
+	MatchID    uint   `json:"matchID" gorm:"not null;index"`
+	PlayerID   uint   `json:"playerID" gorm:"not null"`
+	Player     Player `json:"player" gorm:"foreignKey:PlayerID"`
+	Seed       int    `json:"seed" gorm:"not null"`
+	Position   *int   `json:"position"`
+}
+
+type StartFinalsRequest struct {
+	Format     FinalsFormat `json:"format" binding:"required,oneof=PAPA HEAD_TO_HEAD"`
+	Qualifiers int          `json:"qualifiers" binding:"required,min=2"`
+}
+
+type FinalsPositionRequest struct {
+	PlayerID uint `json:"playerID" binding:"required"`
+	Position int  `json:"position" binding:"required,min=1"`
+}
+
+type FinalsMatchResultRequest struct {
+	Results []FinalsPositionRequest `json:"results" binding:"required,min=2,dive"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Game is a single game played on one machine by a group of players during an event
+type Game struct {
+	gorm.Model `swaggerignore:"true"`
+	EventID    uint         `json:"eventID" gorm:"not null;index"`
+	GroupID    *uint        `json:"groupID" gorm:"index"`
+	Round      int          `json:"round"`
+	MachineID  uint         `json:"machineID" gorm:"not null"`
+	Machine    Machine      `json:"machine" gorm:"foreignKey:MachineID"`
+	Results    []GameResult `json:"results" gorm:"foreignKey:GameID"`
+}
+
+// GameResult records where a player finished in a game
+type GameResult struct {
+	gorm.Model `swaggerignore:"true"`
+	GameID     uint   `json:"gameID" gorm:"not null;index"`
+	PlayerID   uint   `json:"playerID" gorm:"not null;index"`
+	Player     Player `json:"player" gorm:"foreignKey:PlayerID"`
+	Position   int    `json:"position" gorm:"not null"`
+	Score      *int64 `json:"score"`
+}
+
+type GameResultRequest struct {
+	PlayerID uint   `json:"playerID" binding:"required"`
+	Position int    `json:"position" binding:"required,min=1"`
+	Score    *int64 `json:"score"`
+}
+
+type CreateGameRequest struct {
+	GroupID   *uint               `json:"groupID"`
+	Round     int                 `json:"round" binding:"min=0"`
+	MachineID uint                `json:"machineID" binding:"required"`
+	Results   []GameResultRequest `json:"results" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Group is a pod of players who play their games together during an event
+type Group struct {
+	gorm.Model     `swaggerignore:"true"`
+	EventID        uint     `json:"eventID" gorm:"not null;index"`
+	Number         int      `json:"number" gorm:"not null"`
+	IsWinnersGroup bool     `json:"isWinnersGroup" gorm:"not null"`
+	Players        []Player `json:"players" gorm:"many2many:group_players;"`
+}
+
+type GroupRequest struct {
+	PlayerIDs      []uint `json:"playerIDs" binding:"required,min=3,max=4"`
+	IsWinnersGroup bool   `json:"isWinnersGroup"`
+}
+
+type UpdateGroupsRequest struct {
+	Groups []GroupRequest `json:"groups" binding:"required,min=1,dive"`
+}
@@ -6,3 +6,16 @@ const (
 	GroupOrderingRandom GroupOrdering = "RANDOM"
 	GroupOrderingSeeded GroupOrdering = "SEEDED"
 )
+
+// DefaultGroupOrdering is used for events created without a group ordering
+const DefaultGroupOrdering = GroupOrderingSeeded
+
+// Values lists every group ordering
+func (GroupOrdering) Values() []string {
+	return []string{string(GroupOrderingRandom), string(GroupOrderingSeeded)}
+}
+
+// Valid reports whether o is one of the known group orderings
+func (o GroupOrdering) Valid() bool {
+	return isEnumValue(o.Values(), string(o))
+}
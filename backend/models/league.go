@@ -14,3 +14,9 @@ type League struct {
 	OwnerID     uint      `json:"ownerID" gorm:"not null"`
 	Owner       User      `json:"owner" gorm:"foreignKey:OwnerID"`
 }
+
+// UpdateLeagueRequest changes a league's details. Omitted fields are left unchanged.
+type UpdateLeagueRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1"`
+	Location *string `json:"location" binding:"omitempty,min=1"`
+}
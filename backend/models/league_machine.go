@@ -0,0 +1,33 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// LeagueMachine is a machine in a league's bank, the games available at its venue
+type LeagueMachine struct {
+	gorm.Model `swaggerignore:"true"`
+	LeagueID   uint    `json:"leagueID" gorm:"not null;uniqueIndex:idx_league_machine"`
+	MachineID  uint    `json:"machineID" gorm:"not null;uniqueIndex:idx_league_machine"`
+	Machine    Machine `json:"machine" gorm:"foreignKey:MachineID"`
+	IsActive   bool    `json:"isActive" gorm:"not null;default:true"` // Out-of-order machines are inactive and cannot be scheduled
+	Notes      string  `json:"notes"`
+}
+
+// AddLeagueMachineRequest adds a machine to a league's bank by its OPDB ID
+type AddLeagueMachineRequest struct {
+	OPDBID string `json:"opdbID" binding:"required"`
+	Notes  string `json:"notes"`
+}
+
+// UpdateLeagueMachineRequest marks a bank machine in or out of order, or changes its notes.
+// Omitted fields are left unchanged.
+type UpdateLeagueMachineRequest struct {
+	IsActive *bool   `json:"isActive"`
+	Notes    *string `json:"notes"`
+}
+
+// AttachMachinesRequest adds machines from the league's bank to an event
+type AttachMachinesRequest struct {
+	MachineIDs []uint `json:"machineIDs" binding:"required,min=1"`
+}
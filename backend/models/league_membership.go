@@ -0,0 +1,43 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+type LeagueRole string
+
+const (
+	// LeagueRoleOwner can do anything in the league, including managing its admins
+	LeagueRoleOwner LeagueRole = "OWNER"
+	// LeagueRoleAdmin can configure seasons and run events, and manage scorekeepers
+	LeagueRoleAdmin LeagueRole = "ADMIN"
+	// LeagueRoleScorekeeper can enter game and finals results but cannot change any configuration
+	LeagueRoleScorekeeper LeagueRole = "SCOREKEEPER"
+)
+
+// leagueRoleRanks orders the roles so that each role can do everything the roles below it can
+var leagueRoleRanks = map[LeagueRole]int{
+	LeagueRoleScorekeeper: 1,
+	LeagueRoleAdmin:       2,
+	LeagueRoleOwner:       3,
+}
+
+// Includes reports whether the role grants at least the permissions of the other role
+func (r LeagueRole) Includes(other LeagueRole) bool {
+	return leagueRoleRanks[r] > 0 && leagueRoleRanks[r] >= leagueRoleRanks[other]
+}
+
+// LeagueMembership gives a user a staff role in a league
+type LeagueMembership struct {
+	gorm.Model `swaggerignore:"true"`
+	LeagueID   uint       `json:"leagueID" gorm:"not null;uniqueIndex:idx_league_membership"`
+	UserID     uint       `json:"userID" gorm:"not null;uniqueIndex:idx_league_membership"`
+	User       User       `json:"user" gorm:"foreignKey:UserID"`
+	Role       LeagueRole `json:"role" gorm:"type:string;not null"`
+}
+
+// AddLeagueMemberRequest invites an existing user to a league's staff by email
+type AddLeagueMemberRequest struct {
+	Email string     `json:"email" binding:"required,email"`
+	Role  LeagueRole `json:"role" binding:"required,oneof=ADMIN SCOREKEEPER"`
+}
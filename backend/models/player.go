@@ -1,13 +1,53 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Player struct {
-	gorm.Model `swaggerignore:"true"`
-	Name       string `json:"name" gorm:"not null"`
-	LeagueID   uint   `json:"leagueID" gorm:"not null"`
-	League     League `json:"league" gorm:"foreignKey:LeagueID"`
-	IFPANumber string `json:"ifpaNumber" gorm:""`
+	gorm.Model    `swaggerignore:"true"`
+	Name          string     `json:"name" gorm:"not null"`
+	LeagueID      uint       `json:"leagueID" gorm:"not null"`
+	League        League     `json:"league" gorm:"foreignKey:LeagueID"`
+	IFPANumber    string     `json:"ifpaNumber" gorm:""`
+	IsArchived    bool       `json:"isArchived" gorm:"not null;default:false"` // Archived players keep their results but are hidden from the league's player list
+	IFPARank      int        `json:"ifpaRank"`                                 // Current WPPR world rank, 0 if unranked
+	IFPAPoints    float64    `json:"ifpaPoints"`                               // Current WPPR points
+	IFPARating    float64    `json:"ifpaRating"`                               // Glicko rating
+	IFPAFetchedAt *time.Time `json:"ifpaFetchedAt"`                            // When the IFPA figures were last fetched, nil if never
+}
+
+// CreatePlayerRequest adds a player to a league by name, for guests and players without an IFPA number
+type CreatePlayerRequest struct {
+	Name       string `json:"name" binding:"required"`
+	IFPANumber string `json:"ifpaNumber"`
+}
+
+// AddPlayersByIFPARequest adds players to a league by looking their names up on IFPA
+type AddPlayersByIFPARequest struct {
+	IFPANumbers []int `json:"ifpaNumbers" binding:"required,min=1,max=100,dive,gt=0"`
+}
+
+// IFPAImportStatus is the outcome for one number in a bulk IFPA add
+type IFPAImportStatus string
+
+const (
+	IFPAImportAdded          IFPAImportStatus = "ADDED"
+	IFPAImportAlreadyPresent IFPAImportStatus = "ALREADY_PRESENT"
+	IFPAImportNotFound       IFPAImportStatus = "NOT_FOUND"
+	IFPAImportError          IFPAImportStatus = "ERROR"
+)
+
+// MergePlayersRequest names the duplicate player whose records are moved onto the surviving player
+type MergePlayersRequest struct {
+	SourcePlayerID uint `json:"sourcePlayerID" binding:"required"`
+}
+
+// UpdatePlayerRequest changes a player's details. Omitted fields are left unchanged.
+type UpdatePlayerRequest struct {
+	Name       *string `json:"name" binding:"omitempty,min=1"`
+	IFPANumber *string `json:"ifpaNumber"`
+	IsArchived *bool   `json:"isArchived"`
 }
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// ScheduledGame assigns a group to a machine for one round of an event.
+// GameID is set once the result of the game has been recorded.
+type ScheduledGame struct {
+	gorm.Model `swaggerignore:"true"`
+	EventID    uint    `json:"eventID" gorm:"not null;index"`
+	Round      int     `json:"round" gorm:"not null"`
+	GroupID    uint    `json:"groupID" gorm:"not null"`
+	Group      Group   `json:"group" gorm:"foreignKey:GroupID"`
+	MachineID  uint    `json:"machineID" gorm:"not null"`
+	Machine    Machine `json:"machine" gorm:"foreignKey:MachineID"`
+	GameID     *uint   `json:"gameID"`
+}
+
+type GenerateScheduleRequest struct {
+	GamesPerGroup int `json:"gamesPerGroup" binding:"required,min=1"`
+}
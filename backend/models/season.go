@@ -16,11 +16,38 @@ type Season struct {
 	EventCount        int                  `json:"eventCount"`
 	HasFinals         bool                 `json:"hasFinals"`
 	PointDistribution PointDistributionMap `json:"pointDistribution" gorm:"type:json"`
+	StartDate         *time.Time           `json:"startDate,omitempty"` // Events must fall on or after this, when set
+	EndDate           *time.Time           `json:"endDate,omitempty"`   // Events must fall on or before this, when set
 	CreatedAt         time.Time            `json:"created_at"`
 }
 
+// Contains reports whether date falls within the season's start and end
+// dates. A season without dates contains every date.
+func (s *Season) Contains(date time.Time) bool {
+	if s.StartDate != nil && date.Before(*s.StartDate) {
+		return false
+	}
+	if s.EndDate != nil && date.After(*s.EndDate) {
+		return false
+	}
+	return true
+}
+
 type CreateSeasonRequest struct {
-	Name          string `json:"name" binding:"required"`
-	CountingGames int    `json:"countingGames" binding:"required"`
-	HasFinals     bool   `json:"hasFinals"`
+	Name              string               `json:"name" binding:"required"`
+	CountingGames     int                  `json:"countingGames" binding:"required,min=1"`
+	HasFinals         bool                 `json:"hasFinals"`
+	PointDistribution PointDistributionMap `json:"pointDistribution"`
+	StartDate         *string              `json:"startDate" binding:"omitempty,rfc3339" example:"2024-01-01T00:00:00Z"`
+	EndDate           *string              `json:"endDate" binding:"omitempty,rfc3339" example:"2024-06-30T23:59:59Z"`
+}
+
+// UpdateSeasonRequest changes a season's configuration. Omitted fields are left unchanged.
+type UpdateSeasonRequest struct {
+	Name              *string              `json:"name" binding:"omitempty,min=1"`
+	CountingGames     *int                 `json:"countingGames" binding:"omitempty,min=1"`
+	HasFinals         *bool                `json:"hasFinals"`
+	PointDistribution PointDistributionMap `json:"pointDistribution"`
+	StartDate         *string              `json:"startDate" binding:"omitempty,rfc3339"`
+	EndDate           *string              `json:"endDate" binding:"omitempty,rfc3339"`
 }
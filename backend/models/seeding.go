@@ -8,3 +8,21 @@ const (
 	SeedingMethodRandom   SeedingMethod = "RANDOM"
 	SeedingMethodIFPARank SeedingMethod = "IFPA_RANK"
 )
+
+// DefaultSeedingMethod is used for events created without a seeding method
+const DefaultSeedingMethod = SeedingMethodAverage
+
+// Values lists every seeding method
+func (SeedingMethod) Values() []string {
+	return []string{
+		string(SeedingMethodAverage),
+		string(SeedingMethodRank),
+		string(SeedingMethodRandom),
+		string(SeedingMethodIFPARank),
+	}
+}
+
+// Valid reports whether m is one of the known seeding methods
+func (m SeedingMethod) Valid() bool {
+	return isEnumValue(m.Values(), string(m))
+}
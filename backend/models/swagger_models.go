@@ -36,4 +36,6 @@ type SwaggerSeason struct {
 	EventCount        uint                 `json:"eventCount" example:"0"`
 	HasFinals         bool                 `json:"hasFinals" example:"false"`
 	PointDistribution PointDistributionMap `json:"pointDistribution"`
+	StartDate         string               `json:"startDate,omitempty" example:"2024-01-01T00:00:00Z"`
+	EndDate           string               `json:"endDate,omitempty" example:"2024-06-30T23:59:59Z"`
 }
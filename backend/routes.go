@@ -0,0 +1,223 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"backend/handlers"
+	"backend/models"
+)
+
+// routeHandlers holds everything the route tables dispatch to
+type routeHandlers struct {
+	auth        *handlers.AuthHandler
+	league      *handlers.LeagueHandler
+	season      *handlers.SeasonHandler
+	event       *handlers.EventHandler
+	group       *handlers.GroupHandler
+	schedule    *handlers.ScheduleHandler
+	game        *handlers.GameHandler
+	finals      *handlers.FinalsHandler
+	machine     *handlers.MachineHandler
+	member      *handlers.MemberHandler
+	player      *handlers.PlayerHandler
+	attendance  *handlers.AttendanceHandler
+	machineBank *handlers.MachineBankHandler
+	authorizer  *handlers.Authorizer
+	loader      *handlers.ResourceLoader
+}
+
+// registerRoutes mounts the API. Everything inside a league is nested under
+// it, so every path names the full chain of parents and the loader can reject
+// a season, event or player that belongs to a different parent.
+func registerRoutes(api *gin.RouterGroup, h routeHandlers) {
+	api.Use(h.loader.LoadPathResources)
+
+	const (
+		league = "/leagues/:leagueID"
+		season = league + "/seasons/:seasonID"
+		event  = season + "/events/:eventID"
+	)
+
+	// Public routes
+	api.POST("/auth/signup", h.auth.Signup)
+	api.POST("/auth/login", h.auth.Login)
+	api.POST("/auth/refresh", h.auth.Refresh)
+	api.GET("/leagues", h.league.ListLeagues)
+	api.GET(league, h.league.GetLeague)
+	api.GET(league+"/players", h.league.ListPlayers)
+	api.GET(league+"/machines", h.machineBank.ListLeagueMachines)
+	api.GET(league+"/seasons", h.season.ListSeasons)
+	api.GET(season, h.season.GetSeason)
+	api.GET(season+"/standings", h.season.GetStandings)
+	api.GET(season+"/unattended_players", h.attendance.ListUnattendedPlayers)
+	api.GET(season+"/events", h.event.ListEvents)
+	api.GET(event, h.event.GetEvent)
+	api.GET(event+"/players", h.attendance.ListEventPlayers)
+	api.GET(event+"/machines", h.machineBank.ListEventMachines)
+	api.GET(event+"/seeding", h.event.GetSeeding)
+	api.GET(event+"/groups", h.group.ListGroups)
+	api.GET(event+"/schedule", h.schedule.GetSchedule)
+	api.GET(event+"/games", h.game.ListGames)
+	api.GET(event+"/finals", h.finals.GetFinals)
+	api.GET("/machines/search", h.machine.SearchMachines)
+	api.GET("/machines/:opdb_id", h.machine.GetMachine)
+
+	// Protected routes
+	protected := api.Group("")
+	protected.Use(h.auth.AuthMiddleware)
+	{
+		protected.GET("/auth/me", h.auth.GetCurrentUser)
+		protected.POST("/auth/logout", h.auth.Logout)
+		protected.POST("/leagues", h.league.CreateLeague)
+	}
+
+	// Routes that change a league, or anything within it, need a staff role in that league
+	staff := protected.Group("")
+	staff.Use(h.authorizer.RequireLeagueRole(models.LeagueRoleScorekeeper))
+	{
+		staff.GET(league+"/members", h.member.ListMembers)
+		staff.PATCH(league+"/machines/:machineID", h.machineBank.UpdateLeagueMachine)
+		// Attendance routes
+		staff.POST(event+"/players", h.attendance.CheckInPlayers)
+		staff.POST(event+"/players/:playerID", h.attendance.CheckInPlayer)
+		staff.DELETE(event+"/players/:playerID", h.attendance.CheckOutPlayer)
+		// Game routes
+		staff.POST(event+"/games", h.game.CreateGame)
+		staff.DELETE(event+"/games/:gameID", h.game.DeleteGame)
+		// Finals routes
+		staff.POST(event+"/finals/matches/:matchID/results", h.finals.RecordMatchResult)
+	}
+
+	admin := protected.Group("")
+	admin.Use(h.authorizer.RequireLeagueRole(models.LeagueRoleAdmin))
+	{
+		// League routes
+		admin.PATCH(league, h.league.UpdateLeague)
+		admin.POST(league+"/members", h.member.AddMember)
+		admin.DELETE(league+"/members/:userID", h.member.RemoveMember)
+		admin.POST(league+"/machines", h.machineBank.AddLeagueMachine)
+		admin.DELETE(league+"/machines/:machineID", h.machineBank.RemoveLeagueMachine)
+		// Player routes
+		admin.POST(league+"/players", h.player.CreatePlayer)
+		admin.POST(league+"/players/ifpa", h.league.AddPlayersByIFPA)
+		admin.POST(league+"/players/ifpa-sync", h.league.SyncIFPAPlayers)
+		admin.PATCH(league+"/players/:playerID", h.player.UpdatePlayer)
+		admin.DELETE(league+"/players/:playerID", h.player.DeletePlayer)
+		admin.POST(league+"/players/:playerID/merge", h.player.MergePlayers)
+		// Season routes
+		admin.POST(league+"/seasons", h.season.CreateSeason)
+		admin.PATCH(season, h.season.UpdateSeason)
+		admin.DELETE(season, h.season.DeleteSeason)
+		// Event routes
+		admin.POST(season+"/events", h.event.CreateEvent)
+		admin.PATCH(event, h.event.UpdateEvent)
+		admin.DELETE(event, h.event.DeleteEvent)
+		admin.POST(event+"/complete", h.event.CompleteEvent)
+		admin.POST(event+"/machines", h.machineBank.AttachEventMachines)
+		admin.DELETE(event+"/machines/:machineID", h.machineBank.DetachEventMachine)
+		// Group routes
+		admin.POST(event+"/groups/generate", h.group.GenerateGroups)
+		admin.PUT(event+"/groups", h.group.UpdateGroups)
+		// Schedule routes
+		admin.POST(event+"/schedule", h.schedule.GenerateSchedule)
+		// Finals routes
+		admin.POST(event+"/finals", h.finals.StartFinals)
+	}
+
+	owner := protected.Group("")
+	owner.Use(h.authorizer.RequireLeagueRole(models.LeagueRoleOwner))
+	{
+		owner.DELETE(league, h.league.DeleteLeague)
+		// Reopening locked results is kept to the owner
+		owner.POST(event+"/reopen", h.event.ReopenEvent)
+	}
+}
+
+// registerLegacyRoutes mounts the original flat routes, which address
+// seasons, events and players by their own ID alone. They are deprecated in
+// favour of /api/v1 and will be removed in the next release.
+func registerLegacyRoutes(api *gin.RouterGroup, h routeHandlers) {
+	api.Use(handlers.Deprecated, h.loader.LoadPathResources)
+
+	// Public routes
+	api.POST("/auth/signup", h.auth.Signup)
+	api.POST("/auth/login", h.auth.Login)
+	api.POST("/auth/refresh", h.auth.Refresh)
+	api.GET("/leagues", h.league.ListLeagues)
+	api.GET("/leagues/:leagueID", h.league.GetLeague)
+	api.GET("/leagues/:leagueID/seasons", h.season.ListSeasons)
+	api.GET("/leagues/:leagueID/players", h.league.ListPlayers)
+	api.GET("/leagues/:leagueID/machines", h.machineBank.ListLeagueMachines)
+	api.GET("/seasons/:seasonID", h.season.GetSeason)
+	api.GET("/seasons/:seasonID/standings", h.season.GetStandings)
+	api.GET("/seasons/:seasonID/events", h.event.ListEvents)
+	api.GET("/seasons/:seasonID/unattended_players", h.attendance.ListUnattendedPlayers)
+	api.GET("/events/:eventID", h.event.GetEvent)
+	api.GET("/events/:eventID/players", h.attendance.ListEventPlayers)
+	api.GET("/events/:eventID/machines", h.machineBank.ListEventMachines)
+	api.GET("/events/:eventID/seeding", h.event.GetSeeding)
+	api.GET("/events/:eventID/groups", h.group.ListGroups)
+	api.GET("/events/:eventID/schedule", h.schedule.GetSchedule)
+	api.GET("/events/:eventID/games", h.game.ListGames)
+	api.GET("/events/:eventID/finals", h.finals.GetFinals)
+	api.GET("/machines/search", h.machine.SearchMachines)
+	api.GET("/machines/:opdb_id", h.machine.GetMachine)
+
+	// Protected routes
+	protected := api.Group("")
+	protected.Use(h.auth.AuthMiddleware)
+	{
+		protected.GET("/auth/me", h.auth.GetCurrentUser)
+		protected.POST("/auth/logout", h.auth.Logout)
+		protected.POST("/leagues/create", h.league.CreateLeague)
+	}
+
+	staff := protected.Group("")
+	staff.Use(h.authorizer.RequireLeagueRole(models.LeagueRoleScorekeeper))
+	{
+		staff.GET("/leagues/:leagueID/members", h.member.ListMembers)
+		staff.POST("/events/:eventID/players", h.attendance.CheckInPlayers)
+		staff.POST("/events/:eventID/players/:playerID", h.attendance.CheckInPlayer)
+		staff.DELETE("/events/:eventID/players/:playerID", h.attendance.CheckOutPlayer)
+		staff.PATCH("/leagues/:leagueID/machines/:machineID", h.machineBank.UpdateLeagueMachine)
+		staff.POST("/events/:eventID/games", h.game.CreateGame)
+		staff.DELETE("/events/:eventID/games/:gameID", h.game.DeleteGame)
+		staff.POST("/events/:eventID/finals/matches/:matchID/results", h.finals.RecordMatchResult)
+	}
+
+	admin := protected.Group("")
+	admin.Use(h.authorizer.RequireLeagueRole(models.LeagueRoleAdmin))
+	{
+		admin.PATCH("/leagues/:leagueID", h.league.UpdateLeague)
+		admin.POST("/leagues/:leagueID/add_players_by_ifpa", h.league.AddPlayersByIFPA)
+		admin.POST("/leagues/:leagueID/players/ifpa-sync", h.league.SyncIFPAPlayers)
+		admin.POST("/leagues/:leagueID/members", h.member.AddMember)
+		admin.DELETE("/leagues/:leagueID/members/:userID", h.member.RemoveMember)
+		admin.POST("/leagues/:leagueID/machines", h.machineBank.AddLeagueMachine)
+		admin.DELETE("/leagues/:leagueID/machines/:machineID", h.machineBank.RemoveLeagueMachine)
+		admin.POST("/events/:eventID/machines", h.machineBank.AttachEventMachines)
+		admin.DELETE("/events/:eventID/machines/:machineID", h.machineBank.DetachEventMachine)
+		admin.POST("/leagues/:leagueID/players", h.player.CreatePlayer)
+		admin.POST("/players/:playerID/merge", h.player.MergePlayers)
+		admin.PATCH("/players/:playerID", h.player.UpdatePlayer)
+		admin.DELETE("/players/:playerID", h.player.DeletePlayer)
+		admin.POST("/leagues/:leagueID/seasons/create", h.season.CreateSeason)
+		admin.PATCH("/seasons/:seasonID", h.season.UpdateSeason)
+		admin.DELETE("/seasons/:seasonID", h.season.DeleteSeason)
+		admin.POST("/seasons/:seasonID/events/create", h.event.CreateEvent)
+		admin.PATCH("/events/:eventID", h.event.UpdateEvent)
+		admin.DELETE("/events/:eventID", h.event.DeleteEvent)
+		admin.POST("/events/:eventID/complete", h.event.CompleteEvent)
+		admin.POST("/events/:eventID/groups/generate", h.group.GenerateGroups)
+		admin.PUT("/events/:eventID/groups", h.group.UpdateGroups)
+		admin.POST("/events/:eventID/schedule", h.schedule.GenerateSchedule)
+		admin.POST("/events/:eventID/finals", h.finals.StartFinals)
+	}
+
+	owner := protected.Group("")
+	owner.Use(h.authorizer.RequireLeagueRole(models.LeagueRoleOwner))
+	{
+		owner.DELETE("/leagues/:leagueID", h.league.DeleteLeague)
+		owner.POST("/events/:eventID/reopen", h.event.ReopenEvent)
+	}
+}
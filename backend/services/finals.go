@@ -0,0 +1,198 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"backend/models"
+)
+
+// FinalsRankingRow is a player's final (or, while the finals are running, provisional) finishing place
+type FinalsRankingRow struct {
+	Rank          int    `json:"rank"`
+	PlayerID      uint   `json:"playerID"`
+	PlayerName    string `json:"playerName"`
+	Seed          int    `json:"seed"`
+	RoundReached  int    `json:"roundReached"`
+	Position      int    `json:"position"`
+	MatchesPlayed int    `json:"matchesPlayed"`
+}
+
+// ValidateQualifierCount checks that the number of qualifiers fills a
+// bracket exactly: a power of two for head-to-head, or four times a power of
+// two for PAPA-style groups so that every round is made of full groups.
+func ValidateQualifierCount(format models.FinalsFormat, qualifiers int) error {
+	switch format {
+	case models.FinalsFormatPAPA:
+		if qualifiers < 4 || qualifiers%4 != 0 || !isPowerOfTwo(qualifiers/4) {
+			return fmt.Errorf("PAPA finals need 4, 8, 16, 32... qualifiers, not %d", qualifiers)
+		}
+	case models.FinalsFormatHeadToHead:
+		if qualifiers < 2 || !isPowerOfTwo(qualifiers) {
+			return fmt.Errorf("head-to-head finals need 2, 4, 8, 16... qualifiers, not %d", qualifiers)
+		}
+	default:
+		return fmt.Errorf("unknown finals format %q", format)
+	}
+	return nil
+}
+
+// BuildFirstRound creates the opening matches of the finals from the
+// qualifying players, given in seed order
+func BuildFirstRound(format models.FinalsFormat, playerIDs []uint) ([]models.FinalsMatch, error) {
+	if err := ValidateQualifierCount(format, len(playerIDs)); err != nil {
+		return nil, err
+	}
+
+	entrants := make([]models.FinalsMatchPlayer, len(playerIDs))
+	for i, playerID := range playerIDs {
+		entrants[i] = models.FinalsMatchPlayer{PlayerID: playerID, Seed: i + 1}
+	}
+
+	if format == models.FinalsFormatPAPA {
+		return groupMatches(entrants, 1), nil
+	}
+
+	ordered := make([]models.FinalsMatchPlayer, len(entrants))
+	for i, seed := range bracketOrder(len(entrants)) {
+		ordered[i] = entrants[seed-1]
+	}
+	return pairMatches(ordered, 1), nil
+}
+
+// NextRound creates the matches for the round after the given completed
+// round. It returns nil when the given round was the final.
+func NextRound(format models.FinalsFormat, matches []models.FinalsMatch) []models.FinalsMatch {
+	if len(matches) <= 1 {
+		return nil
+	}
+
+	sorted := append([]models.FinalsMatch{}, matches...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+
+	advancingPerMatch := 1
+	if format == models.FinalsFormatPAPA {
+		advancingPerMatch = 2
+	}
+
+	var advancing []models.FinalsMatchPlayer
+	for _, match := range sorted {
+		for _, player := range match.Players {
+			if player.Position != nil && *player.Position <= advancingPerMatch {
+				advancing = append(advancing, models.FinalsMatchPlayer{
+					PlayerID: player.PlayerID,
+					Seed:     player.Seed,
+				})
+			}
+		}
+	}
+
+	round := sorted[0].Round + 1
+	if format == models.FinalsFormatPAPA {
+		sort.Slice(advancing, func(i, j int) bool { return advancing[i].Seed < advancing[j].Seed })
+		return groupMatches(advancing, round)
+	}
+	// Head-to-head winners keep their place in the bracket
+	return pairMatches(advancing, round)
+}
+
+// ComputeFinalsRanking ranks every finalist by how far they got, then by
+// where they finished in their last match, then by seed
+func ComputeFinalsRanking(matches []models.FinalsMatch) []FinalsRankingRow {
+	rows := make(map[uint]*FinalsRankingRow)
+	for _, match := range matches {
+		for _, player := range match.Players {
+			row, ok := rows[player.PlayerID]
+			if !ok {
+				row = &FinalsRankingRow{
+					PlayerID:   player.PlayerID,
+					PlayerName: player.Player.Name,
+					Seed:       player.Seed,
+				}
+				rows[player.PlayerID] = row
+			}
+			if player.Position != nil {
+				row.MatchesPlayed++
+			}
+			if match.Round > row.RoundReached {
+				row.RoundReached = match.Round
+				row.Position = 0
+				if player.Position != nil {
+					row.Position = *player.Position
+				}
+			}
+		}
+	}
+
+	ranking := make([]FinalsRankingRow, 0, len(rows))
+	for _, row := range rows {
+		ranking = append(ranking, *row)
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		if a.RoundReached != b.RoundReached {
+			return a.RoundReached > b.RoundReached
+		}
+		if a.Position != b.Position {
+			// Players still waiting to play (position 0) sort after those who have finished
+			if a.Position == 0 || b.Position == 0 {
+				return b.Position == 0
+			}
+			return a.Position < b.Position
+		}
+		return a.Seed < b.Seed
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+
+	return ranking
+}
+
+// groupMatches splits seeded players into four-player groups, spreading the
+// seeds across the groups in snake order
+func groupMatches(entrants []models.FinalsMatchPlayer, round int) []models.FinalsMatch {
+	matches := make([]models.FinalsMatch, len(entrants)/4)
+	for i := range matches {
+		matches[i] = models.FinalsMatch{Round: round, Number: i + 1}
+	}
+	for i, entrant := range entrants {
+		pass, index := i/len(matches), i%len(matches)
+		if pass%2 == 1 {
+			index = len(matches) - 1 - index
+		}
+		matches[index].Players = append(matches[index].Players, entrant)
+	}
+	return matches
+}
+
+// pairMatches pairs consecutive entrants into head-to-head matches
+func pairMatches(entrants []models.FinalsMatchPlayer, round int) []models.FinalsMatch {
+	matches := make([]models.FinalsMatch, 0, len(entrants)/2)
+	for i := 0; i+1 < len(entrants); i += 2 {
+		matches = append(matches, models.FinalsMatch{
+			Round:   round,
+			Number:  len(matches) + 1,
+			Players: []models.FinalsMatchPlayer{entrants[i], entrants[i+1]},
+		})
+	}
+	return matches
+}
+
+// bracketOrder returns seeds 1..size in standard bracket order, so that the
+// top seeds can only meet in the later rounds (1, 8, 4, 5, 2, 7, 3, 6 for 8)
+func bracketOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"backend/models"
+)
+
+func TestBracketOrder(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{2, []int{1, 2}},
+		{4, []int{1, 4, 2, 3}},
+		{8, []int{1, 8, 4, 5, 2, 7, 3, 6}},
+	}
+	for _, tt := range tests {
+		if got := bracketOrder(tt.size); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bracketOrder(%d) = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+// qualifiers returns count player IDs in seed order, seed n having ID 100+n
+func qualifiers(count int) []uint {
+	ids := make([]uint, count)
+	for i := range ids {
+		ids[i] = uint(101 + i)
+	}
+	return ids
+}
+
+// matchSeeds lists the seeds playing in each match
+func matchSeeds(matches []models.FinalsMatch) [][]int {
+	seeds := make([][]int, len(matches))
+	for i, match := range matches {
+		for _, player := range match.Players {
+			seeds[i] = append(seeds[i], player.Seed)
+		}
+	}
+	return seeds
+}
+
+// finish records the result of a match, the players finishing in the given seed order
+func finish(match *models.FinalsMatch, seeds ...int) {
+	for place, seed := range seeds {
+		for i := range match.Players {
+			if match.Players[i].Seed == seed {
+				position := place + 1
+				match.Players[i].Position = &position
+			}
+		}
+	}
+	match.IsComplete = true
+}
+
+func TestBuildFirstRound(t *testing.T) {
+	tests := []struct {
+		name   string
+		format models.FinalsFormat
+		count  int
+		want   [][]int
+	}{
+		{"head-to-head pairs seeds in bracket order", models.FinalsFormatHeadToHead, 8, [][]int{{1, 8}, {4, 5}, {2, 7}, {3, 6}}},
+		{"head-to-head final", models.FinalsFormatHeadToHead, 2, [][]int{{1, 2}}},
+		{"PAPA snakes seeds across groups", models.FinalsFormatPAPA, 8, [][]int{{1, 4, 5, 8}, {2, 3, 6, 7}}},
+		{"PAPA single group", models.FinalsFormatPAPA, 4, [][]int{{1, 2, 3, 4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := BuildFirstRound(tt.format, qualifiers(tt.count))
+			if err != nil {
+				t.Fatalf("BuildFirstRound: %v", err)
+			}
+			if got := matchSeeds(matches); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("seeds = %v, want %v", got, tt.want)
+			}
+			for i, match := range matches {
+				if match.Round != 1 || match.Number != i+1 {
+					t.Errorf("match %d is round %d number %d", i+1, match.Round, match.Number)
+				}
+				for _, player := range match.Players {
+					if player.PlayerID != uint(100+player.Seed) {
+						t.Errorf("seed %d given to player %d", player.Seed, player.PlayerID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuildFirstRoundRejectsQualifierCounts(t *testing.T) {
+	tests := []struct {
+		format models.FinalsFormat
+		count  int
+	}{
+		{models.FinalsFormatHeadToHead, 1},
+		{models.FinalsFormatHeadToHead, 6},
+		{models.FinalsFormatPAPA, 2},
+		{models.FinalsFormatPAPA, 12},
+		{"LADDER", 8},
+	}
+	for _, tt := range tests {
+		if _, err := BuildFirstRound(tt.format, qualifiers(tt.count)); err == nil {
+			t.Errorf("BuildFirstRound(%s, %d) should be rejected", tt.format, tt.count)
+		}
+	}
+}
+
+func TestNextRoundHeadToHead(t *testing.T) {
+	round1, _ := BuildFirstRound(models.FinalsFormatHeadToHead, qualifiers(8))
+	finish(&round1[0], 8, 1) // upset
+	finish(&round1[1], 4, 5)
+	finish(&round1[2], 2, 7)
+	finish(&round1[3], 6, 3) // upset
+	// Matches arrive in any order but are paired by match number
+	round1[0], round1[3] = round1[3], round1[0]
+
+	round2 := NextRound(models.FinalsFormatHeadToHead, round1)
+	if got, want := matchSeeds(round2), [][]int{{8, 4}, {2, 6}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("round 2 seeds = %v, want %v", got, want)
+	}
+	for _, match := range round2 {
+		if match.Round != 2 {
+			t.Errorf("match %d is in round %d, want 2", match.Number, match.Round)
+		}
+		for _, player := range match.Players {
+			if player.Position != nil {
+				t.Errorf("seed %d carried a position into round 2", player.Seed)
+			}
+		}
+	}
+
+	finish(&round2[0], 4, 8)
+	finish(&round2[1], 2, 6)
+	final := NextRound(models.FinalsFormatHeadToHead, round2)
+	if got, want := matchSeeds(final), [][]int{{4, 2}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("final seeds = %v, want %v", got, want)
+	}
+
+	finish(&final[0], 2, 4)
+	if next := NextRound(models.FinalsFormatHeadToHead, final); next != nil {
+		t.Errorf("expected no round after the final, got %v", matchSeeds(next))
+	}
+}
+
+func TestNextRoundPAPA(t *testing.T) {
+	round1, _ := BuildFirstRound(models.FinalsFormatPAPA, qualifiers(16))
+	// Top two of each group advance, reseeded and snaked into new groups
+	finish(&round1[0], 16, 1, 8, 9)
+	finish(&round1[1], 2, 7, 10, 15)
+	finish(&round1[2], 14, 11, 3, 6)
+	finish(&round1[3], 4, 5, 12, 13)
+
+	round2 := NextRound(models.FinalsFormatPAPA, round1)
+	if got, want := matchSeeds(round2), [][]int{{1, 5, 7, 16}, {2, 4, 11, 14}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("round 2 seeds = %v, want %v", got, want)
+	}
+
+	finish(&round2[0], 7, 16, 1, 5)
+	finish(&round2[1], 2, 11, 4, 14)
+	final := NextRound(models.FinalsFormatPAPA, round2)
+	if got, want := matchSeeds(final), [][]int{{2, 7, 11, 16}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("final seeds = %v, want %v", got, want)
+	}
+	if final[0].Round != 3 {
+		t.Errorf("final is round %d, want 3", final[0].Round)
+	}
+}
+
+func TestComputeFinalsRanking(t *testing.T) {
+	round1, _ := BuildFirstRound(models.FinalsFormatHeadToHead, qualifiers(4))
+	finish(&round1[0], 1, 4)
+	finish(&round1[1], 3, 2)
+	final := NextRound(models.FinalsFormatHeadToHead, round1)
+
+	type row struct{ seed, round, position, played int }
+	rows := func(ranking []FinalsRankingRow) []row {
+		var got []row
+		for i, r := range ranking {
+			if r.Rank != i+1 || r.PlayerID != uint(100+r.Seed) {
+				t.Errorf("row %d = %+v", i, r)
+			}
+			got = append(got, row{r.Seed, r.RoundReached, r.Position, r.MatchesPlayed})
+		}
+		return got
+	}
+
+	// While the final is unplayed both finalists lead, ordered by seed
+	provisional := ComputeFinalsRanking(append(append([]models.FinalsMatch{}, round1...), final...))
+	want := []row{{1, 2, 0, 1}, {3, 2, 0, 1}, {2, 1, 2, 1}, {4, 1, 2, 1}}
+	if got := rows(provisional); !reflect.DeepEqual(got, want) {
+		t.Errorf("provisional ranking = %v, want %v", got, want)
+	}
+
+	finish(&final[0], 3, 1)
+	ranking := ComputeFinalsRanking(append(append([]models.FinalsMatch{}, round1...), final...))
+	want = []row{{3, 2, 1, 2}, {1, 2, 2, 2}, {2, 1, 2, 1}, {4, 1, 2, 1}}
+	if got := rows(ranking); !reflect.DeepEqual(got, want) {
+		t.Errorf("final ranking = %v, want %v", got, want)
+	}
+}
+
+func TestComputeFinalsRankingPAPA(t *testing.T) {
+	round1, _ := BuildFirstRound(models.FinalsFormatPAPA, qualifiers(8))
+	finish(&round1[0], 1, 5, 4, 8)
+	finish(&round1[1], 6, 2, 7, 3)
+	final := NextRound(models.FinalsFormatPAPA, round1)
+	finish(&final[0], 5, 2, 1, 6)
+
+	ranking := ComputeFinalsRanking(append(round1, final...))
+
+	var seeds []int
+	for _, r := range ranking {
+		seeds = append(seeds, r.Seed)
+	}
+	// Finalists by final position, then the rest by their position in
+	// round 1, with seed breaking ties between the two groups
+	if want := []int{5, 2, 1, 6, 4, 7, 3, 8}; !reflect.DeepEqual(seeds, want) {
+		t.Errorf("ranking seeds = %v, want %v", seeds, want)
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+
+	"backend/models"
+)
+
+// GroupSizes splits playerCount players into groups of four, using groups of
+// three for the remainder. Counts that cannot be split without a group of one
+// or two players (1, 2 and 5) are rejected.
+func GroupSizes(playerCount int) ([]int, error) {
+	threes := (4 - playerCount%4) % 4
+	fours := (playerCount - 3*threes) / 4
+	if playerCount < 3 || fours < 0 {
+		return nil, fmt.Errorf("%d players cannot be split into groups of three and four", playerCount)
+	}
+
+	sizes := make([]int, 0, fours+threes)
+	for i := 0; i < fours; i++ {
+		sizes = append(sizes, 4)
+	}
+	for i := 0; i < threes; i++ {
+		sizes = append(sizes, 3)
+	}
+	return sizes, nil
+}
+
+// BuildGroups splits seeded players into groups. When winnersGroup is set the
+// top seeds play together in group 1; the remaining players are spread across
+// the other groups in snake order so each group gets a mix of seeds. Groups
+// are numbered by their best seed when ordering is SEEDED, or shuffled using
+// the event seed when ordering is RANDOM.
+func BuildGroups(seeded []SeededPlayer, ordering models.GroupOrdering, winnersGroup bool, seed int64) ([]models.Group, error) {
+	sizes, err := GroupSizes(len(seeded))
+	if err != nil {
+		return nil, err
+	}
+
+	var winners *models.Group
+	remaining := seeded
+	if winnersGroup && len(sizes) > 1 {
+		winners = &models.Group{IsWinnersGroup: true}
+		for _, player := range seeded[:sizes[0]] {
+			winners.Players = append(winners.Players, player.Player)
+		}
+		remaining = seeded[sizes[0]:]
+		sizes = sizes[1:]
+	}
+
+	groups := make([]models.Group, len(sizes))
+	next := 0
+	for pass := 0; next < len(remaining); pass++ {
+		for i := range groups {
+			index := i
+			if pass%2 == 1 {
+				index = len(groups) - 1 - i
+			}
+			if len(groups[index].Players) < sizes[index] && next < len(remaining) {
+				groups[index].Players = append(groups[index].Players, remaining[next].Player)
+				next++
+			}
+		}
+	}
+
+	switch ordering {
+	case models.GroupOrderingRandom:
+		rng := rand.New(rand.NewSource(seed))
+		rng.Shuffle(len(groups), func(i, j int) { groups[i], groups[j] = groups[j], groups[i] })
+	case models.GroupOrderingSeeded, "":
+		// Snake order already leaves groups sorted by their best seed
+	default:
+		return nil, fmt.Errorf("unknown group ordering %q", ordering)
+	}
+
+	if winners != nil {
+		groups = append([]models.Group{*winners}, groups...)
+	}
+	for i := range groups {
+		groups[i].Number = i + 1
+	}
+
+	return groups, nil
+}
+
+// LateArrivalGroup picks the group a player who checks in after groups were
+// generated should join: the smallest group with fewer than four players,
+// leaving the winners group alone. It returns -1 when every group is full.
+func LateArrivalGroup(groups []models.Group) int {
+	best := -1
+	for i, group := range groups {
+		if group.IsWinnersGroup || len(group.Players) >= 4 {
+			continue
+		}
+		if best == -1 || len(group.Players) < len(groups[best].Players) ||
+			(len(group.Players) == len(groups[best].Players) && group.Number < groups[best].Number) {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+func TestGroupSizes(t *testing.T) {
+	tests := []struct {
+		players int
+		want    []int
+		wantErr bool
+	}{
+		{players: 1, wantErr: true},
+		{players: 2, wantErr: true},
+		{players: 3, want: []int{3}},
+		{players: 4, want: []int{4}},
+		{players: 5, wantErr: true},
+		{players: 6, want: []int{3, 3}},
+		{players: 7, want: []int{4, 3}},
+		{players: 8, want: []int{4, 4}},
+		{players: 9, want: []int{3, 3, 3}},
+		{players: 10, want: []int{4, 3, 3}},
+		{players: 11, want: []int{4, 4, 3}},
+		{players: 17, want: []int{4, 4, 3, 3, 3}},
+	}
+	for _, tt := range tests {
+		got, err := GroupSizes(tt.players)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("GroupSizes(%d) error = %v, wantErr %v", tt.players, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GroupSizes(%d) = %v, want %v", tt.players, got, tt.want)
+		}
+	}
+}
+
+// seededPlayers returns count players seeded in order of their IDs
+func seededPlayers(count int) []SeededPlayer {
+	seeded := make([]SeededPlayer, count)
+	for i := range seeded {
+		seeded[i] = SeededPlayer{Seed: i + 1, Player: models.Player{Model: gorm.Model{ID: uint(i + 1)}}}
+	}
+	return seeded
+}
+
+// groupPlayerIDs lists the player IDs in each group
+func groupPlayerIDs(groups []models.Group) [][]uint {
+	ids := make([][]uint, len(groups))
+	for i, group := range groups {
+		for _, player := range group.Players {
+			ids[i] = append(ids[i], player.ID)
+		}
+	}
+	return ids
+}
+
+func TestBuildGroups(t *testing.T) {
+	tests := []struct {
+		name         string
+		players      int
+		winnersGroup bool
+		want         [][]uint
+	}{
+		{
+			name:    "snake order spreads the seeds",
+			players: 8,
+			want:    [][]uint{{1, 4, 5, 8}, {2, 3, 6, 7}},
+		},
+		{
+			name:    "snake order with a group of four and two of three",
+			players: 10,
+			want:    [][]uint{{1, 6, 7, 10}, {2, 5, 8}, {3, 4, 9}},
+		},
+		{
+			name:         "winners group takes the top seeds",
+			players:      11,
+			winnersGroup: true,
+			want:         [][]uint{{1, 2, 3, 4}, {5, 8, 9, 11}, {6, 7, 10}},
+		},
+		{
+			name:         "no winners group with a single group",
+			players:      4,
+			winnersGroup: true,
+			want:         [][]uint{{1, 2, 3, 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := BuildGroups(seededPlayers(tt.players), models.GroupOrderingSeeded, tt.winnersGroup, 1)
+			if err != nil {
+				t.Fatalf("BuildGroups: %v", err)
+			}
+			if got := groupPlayerIDs(groups); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groups = %v, want %v", got, tt.want)
+			}
+			for i, group := range groups {
+				if group.Number != i+1 {
+					t.Errorf("group %d numbered %d", i+1, group.Number)
+				}
+				if wantWinners := tt.winnersGroup && i == 0 && len(groups) > 1; group.IsWinnersGroup != wantWinners {
+					t.Errorf("group %d IsWinnersGroup = %v, want %v", group.Number, group.IsWinnersGroup, wantWinners)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildGroupsRandomOrdering(t *testing.T) {
+	seeded, err := BuildGroups(seededPlayers(16), models.GroupOrderingSeeded, true, 42)
+	if err != nil {
+		t.Fatalf("BuildGroups: %v", err)
+	}
+	random, err := BuildGroups(seededPlayers(16), models.GroupOrderingRandom, true, 42)
+	if err != nil {
+		t.Fatalf("BuildGroups: %v", err)
+	}
+	again, _ := BuildGroups(seededPlayers(16), models.GroupOrderingRandom, true, 42)
+
+	if !reflect.DeepEqual(groupPlayerIDs(random), groupPlayerIDs(again)) {
+		t.Error("the same event seed produced different group orders")
+	}
+	if !random[0].IsWinnersGroup || !reflect.DeepEqual(groupPlayerIDs(random)[0], groupPlayerIDs(seeded)[0]) {
+		t.Errorf("winners group should stay first, got %v", groupPlayerIDs(random))
+	}
+
+	// Shuffling reorders whole groups without changing who plays together
+	seededIDs, randomIDs := groupPlayerIDs(seeded), groupPlayerIDs(random)
+	for _, group := range randomIDs {
+		found := false
+		for _, want := range seededIDs {
+			found = found || reflect.DeepEqual(group, want)
+		}
+		if !found {
+			t.Errorf("random ordering produced an unknown group %v", group)
+		}
+	}
+	if reflect.DeepEqual(seededIDs, randomIDs) {
+		t.Error("random ordering left the groups in seeded order")
+	}
+	for i, group := range random {
+		if group.Number != i+1 {
+			t.Errorf("group %d numbered %d", i+1, group.Number)
+		}
+	}
+
+	if _, err := BuildGroups(seededPlayers(8), "SIDEWAYS", false, 1); err == nil {
+		t.Error("expected an unknown ordering to be rejected")
+	}
+	if _, err := BuildGroups(seededPlayers(5), models.GroupOrderingSeeded, false, 1); err == nil {
+		t.Error("expected 5 players to be rejected")
+	}
+}
+
+func TestLateArrivalGroup(t *testing.T) {
+	group := func(number, size int, winners bool) models.Group {
+		return models.Group{Number: number, IsWinnersGroup: winners, Players: make([]models.Player, size)}
+	}
+
+	tests := []struct {
+		name   string
+		groups []models.Group
+		want   int
+	}{
+		{"smallest group", []models.Group{group(1, 4, false), group(2, 3, false), group(3, 4, false)}, 1},
+		{"lowest number among equally small groups", []models.Group{group(1, 4, false), group(2, 3, false), group(3, 3, false)}, 1},
+		{"winners group is left alone", []models.Group{group(1, 3, true), group(2, 4, false), group(3, 3, false)}, 2},
+		{"every group full", []models.Group{group(1, 4, false), group(2, 4, false)}, -1},
+		{"only the winners group has room", []models.Group{group(1, 3, true), group(2, 4, false)}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LateArrivalGroup(tt.groups); got != tt.want {
+				t.Errorf("LateArrivalGroup = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import "sync"
+
+// IFPALookup is the outcome of looking up one IFPA number
+type IFPALookup struct {
+	IFPANumber int
+	Player     *IFPAPlayer
+	Err        error
+}
+
+// LookupIFPAPlayers fetches players from IFPA using at most workers
+// concurrent requests. Results are returned in the same order as numbers.
+func LookupIFPAPlayers(registry PlayerRegistry, numbers []int, workers int) []IFPALookup {
+	results := make([]IFPALookup, len(numbers))
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(numbers)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				player, err := registry.GetPlayerByIFPANumber(numbers[i])
+				results[i] = IFPALookup{IFPANumber: numbers[i], Player: player, Err: err}
+			}
+		}()
+	}
+	for i := range numbers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
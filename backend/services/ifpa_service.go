@@ -2,10 +2,12 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,26 +26,127 @@ type IFPAPlayer struct {
 	State     string `json:"state"`
 }
 
+type IFPAPlayerStats struct {
+	CurrentWPPRRank  json.Number `json:"current_wppr_rank"`
+	CurrentWPPRValue json.Number `json:"current_wppr_value"`
+	RatingsValue     json.Number `json:"ratings_value"`
+}
+
+// IFPARanking is a player's standing in the IFPA world rankings
+type IFPARanking struct {
+	Rank       int     // Current WPPR rank, 0 if unranked
+	WPPRPoints float64 // Current WPPR points
+	Rating     float64 // Glicko rating
+}
+
+// ErrIFPAPlayerNotFound is returned when IFPA has no player with the requested number
+var ErrIFPAPlayerNotFound = errors.New("player not found in IFPA")
+
+// IFPAAPIError is an unexpected response from the IFPA API
+type IFPAAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *IFPAAPIError) Error() string {
+	return fmt.Sprintf("IFPA API error: %d %s - %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// Temporary reports whether the request may succeed if retried
+func (e *IFPAAPIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
 type IFPAPlayerResponse struct {
-	Player IFPAPlayer `json:"player"`
+	Player      IFPAPlayer      `json:"player"`
+	PlayerStats IFPAPlayerStats `json:"player_stats"`
+}
+
+// IFPAConfig configures an IFPAService. Empty fields fall back to the public
+// API and a client with a 10 second timeout. Without an API key the service
+// is disabled and every lookup returns ErrIFPADisabled.
+type IFPAConfig struct {
+	APIKey     string
+	BaseURL    string
+	HTTPClient *http.Client
 }
 
-func NewIFPAService() *IFPAService {
-	apiKey := os.Getenv("IFPA_API_KEY")
-	if apiKey == "" {
-		panic("IFPA_API_KEY environment variable is required")
+func NewIFPAService(config IFPAConfig) *IFPAService {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.ifpapinball.com/v1"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: time.Second * 10}
 	}
 
 	return &IFPAService{
-		apiKey:  apiKey,
-		baseURL: "https://api.ifpapinball.com/v1",
-		httpClient: &http.Client{
-			Timeout: time.Second * 10,
-		},
+		apiKey:     config.APIKey,
+		baseURL:    strings.TrimRight(config.BaseURL, "/"),
+		httpClient: config.HTTPClient,
 	}
 }
 
+// Enabled reports whether an API key is configured
+func (s *IFPAService) Enabled() bool {
+	return s.apiKey != ""
+}
+
 func (s *IFPAService) GetPlayerByIFPANumber(ifpaNumber int) (*IFPAPlayer, error) {
+	response, err := s.fetchPlayer(ifpaNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Player, nil
+}
+
+// GetPlayerRank returns the player's current IFPA world ranking, or 0 if the player is unranked
+func (s *IFPAService) GetPlayerRank(ifpaNumber int) (int, error) {
+	ranking, err := s.GetPlayerRanking(ifpaNumber)
+	if err != nil {
+		return 0, err
+	}
+	return ranking.Rank, nil
+}
+
+// GetPlayerRanking returns the player's current rank, WPPR points and rating.
+// Figures IFPA leaves blank are returned as zero.
+func (s *IFPAService) GetPlayerRanking(ifpaNumber int) (*IFPARanking, error) {
+	response, err := s.fetchPlayer(ifpaNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := response.PlayerStats
+	var ranking IFPARanking
+	if stats.CurrentWPPRRank != "" {
+		rank, err := strconv.Atoi(stats.CurrentWPPRRank.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid IFPA rank %q: %w", stats.CurrentWPPRRank, err)
+		}
+		ranking.Rank = rank
+	}
+	if stats.CurrentWPPRValue != "" {
+		points, err := stats.CurrentWPPRValue.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid IFPA WPPR points %q: %w", stats.CurrentWPPRValue, err)
+		}
+		ranking.WPPRPoints = points
+	}
+	if stats.RatingsValue != "" {
+		rating, err := stats.RatingsValue.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid IFPA rating %q: %w", stats.RatingsValue, err)
+		}
+		ranking.Rating = rating
+	}
+	return &ranking, nil
+}
+
+func (s *IFPAService) fetchPlayer(ifpaNumber int) (*IFPAPlayerResponse, error) {
+	if !s.Enabled() {
+		return nil, ErrIFPADisabled
+	}
+
 	url := fmt.Sprintf("%s/player/%d", s.baseURL, ifpaNumber)
 
 	req, err := http.NewRequest("GET", url, nil)
@@ -59,9 +162,12 @@ func (s *IFPAService) GetPlayerByIFPANumber(ifpaNumber int) (*IFPAPlayer, error)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrIFPAPlayerNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("IFPA API error: %s - %s", resp.Status, string(body))
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &IFPAAPIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var response IFPAPlayerResponse
@@ -69,5 +175,5 @@ func (s *IFPAService) GetPlayerByIFPANumber(ifpaNumber int) (*IFPAPlayer, error)
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return &response.Player, nil
+	return &response, nil
 }
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIFPAServiceDisabledWithoutAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected IFPA request %s", r.URL)
+	}))
+	defer server.Close()
+	service := NewIFPAService(IFPAConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+
+	if service.Enabled() {
+		t.Error("service without an API key should be disabled")
+	}
+	if _, err := service.GetPlayerByIFPANumber(1234); !errors.Is(err, ErrIFPADisabled) {
+		t.Errorf("GetPlayerByIFPANumber error = %v, want ErrIFPADisabled", err)
+	}
+	if _, err := service.GetPlayerRanking(1234); !errors.Is(err, ErrIFPADisabled) {
+		t.Errorf("GetPlayerRanking error = %v, want ErrIFPADisabled", err)
+	}
+}
+
+func TestIFPAServiceUsesConfiguredBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/player/1234" {
+			t.Errorf("request path = %q, want /v2/player/1234", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization header = %q", got)
+		}
+		w.Write([]byte(`{"player":{"id":1234,"first_name":"Keith","last_name":"Elwin"},"player_stats":{"current_wppr_rank":"3","current_wppr_value":"812.5","ratings_value":"1950.25"}}`))
+	}))
+	defer server.Close()
+	service := NewIFPAService(IFPAConfig{APIKey: "test-key", BaseURL: server.URL + "/v2/", HTTPClient: server.Client()})
+
+	ranking, err := service.GetPlayerRanking(1234)
+	if err != nil {
+		t.Fatalf("GetPlayerRanking: %v", err)
+	}
+	if ranking.Rank != 3 || ranking.WPPRPoints != 812.5 || ranking.Rating != 1950.25 {
+		t.Errorf("unexpected ranking %+v", ranking)
+	}
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrSyncInProgress is returned when a league is already being synced
+var ErrSyncInProgress = errors.New("an IFPA sync is already running for this league")
+
+// IFPASyncFailure records a player whose IFPA figures could not be refreshed
+type IFPASyncFailure struct {
+	PlayerID   uint   `json:"playerID"`
+	IFPANumber string `json:"ifpaNumber"`
+	Error      string `json:"error"`
+}
+
+// IFPASyncResult summarises a league sync
+type IFPASyncResult struct {
+	Synced   int               `json:"synced"`
+	Failures []IFPASyncFailure `json:"failures"`
+}
+
+// IFPASyncConfig configures an IFPASyncService. Empty fields fall back to at
+// most four requests a second and three attempts per player, starting with a
+// one second backoff that doubles after each failure.
+type IFPASyncConfig struct {
+	RequestInterval time.Duration
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+}
+
+// IFPASyncService refreshes the IFPA rank, points and rating stored on
+// players. Requests from every sync share one rate limit.
+type IFPASyncService struct {
+	db              *gorm.DB
+	ifpaService     PlayerRegistry
+	requestInterval time.Duration
+	maxAttempts     int
+	retryBackoff    time.Duration
+	sleep           func(time.Duration)
+
+	limiterMu   sync.Mutex
+	nextRequest time.Time
+
+	runningMu sync.Mutex
+	running   map[uint]bool
+}
+
+func NewIFPASyncService(db *gorm.DB, ifpaService PlayerRegistry, config IFPASyncConfig) *IFPASyncService {
+	if config.RequestInterval <= 0 {
+		config.RequestInterval = 250 * time.Millisecond
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 3
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = time.Second
+	}
+
+	return &IFPASyncService{
+		db:              db,
+		ifpaService:     ifpaService,
+		requestInterval: config.RequestInterval,
+		maxAttempts:     config.MaxAttempts,
+		retryBackoff:    config.RetryBackoff,
+		sleep:           time.Sleep,
+		running:         make(map[uint]bool),
+	}
+}
+
+// SyncLeague refreshes every player in the league who has an IFPA number.
+// A player who cannot be fetched is reported in the result and keeps their
+// previous figures; the sync carries on with the rest of the league.
+func (s *IFPASyncService) SyncLeague(leagueID uint) (*IFPASyncResult, error) {
+	if !s.ifpaService.Enabled() {
+		return nil, ErrIFPADisabled
+	}
+	if !s.start(leagueID) {
+		return nil, ErrSyncInProgress
+	}
+	defer s.finish(leagueID)
+
+	var players []models.Player
+	if err := s.db.Where("league_id = ? AND ifpa_number <> ''", leagueID).Order("id").Find(&players).Error; err != nil {
+		return nil, fmt.Errorf("failed to load players: %w", err)
+	}
+
+	result := &IFPASyncResult{Failures: []IFPASyncFailure{}}
+	for i := range players {
+		if err := s.SyncPlayer(&players[i]); err != nil {
+			result.Failures = append(result.Failures, IFPASyncFailure{
+				PlayerID:   players[i].ID,
+				IFPANumber: players[i].IFPANumber,
+				Error:      err.Error(),
+			})
+			continue
+		}
+		result.Synced++
+	}
+
+	return result, nil
+}
+
+// SyncPlayer fetches the player's current IFPA figures and stores them on the
+// player, retrying failures that may be temporary
+func (s *IFPASyncService) SyncPlayer(player *models.Player) error {
+	ifpaNumber, err := strconv.Atoi(player.IFPANumber)
+	if err != nil {
+		return fmt.Errorf("invalid IFPA number %q", player.IFPANumber)
+	}
+
+	ranking, err := s.fetchRanking(ifpaNumber)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	player.IFPARank = ranking.Rank
+	player.IFPAPoints = ranking.WPPRPoints
+	player.IFPARating = ranking.Rating
+	player.IFPAFetchedAt = &now
+	return s.db.Model(player).Select("ifpa_rank", "ifpa_points", "ifpa_rating", "ifpa_fetched_at").Updates(player).Error
+}
+
+// fetchRanking requests a ranking within the rate limit, backing off and
+// retrying on network errors, rate limiting and server errors
+func (s *IFPASyncService) fetchRanking(ifpaNumber int) (*IFPARanking, error) {
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		s.wait()
+		ranking, err := s.ifpaService.GetPlayerRanking(ifpaNumber)
+		if err == nil {
+			return ranking, nil
+		}
+		if attempt == s.maxAttempts || !retryable(err) {
+			return nil, err
+		}
+		s.sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// retryable reports whether a failed IFPA request is worth repeating
+func retryable(err error) bool {
+	if errors.Is(err, ErrIFPADisabled) || errors.Is(err, ErrIFPAPlayerNotFound) {
+		return false
+	}
+	var apiErr *IFPAAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	// Transport errors and undecodable responses
+	return true
+}
+
+// wait blocks until the next request is allowed under the rate limit
+func (s *IFPASyncService) wait() {
+	s.limiterMu.Lock()
+	now := time.Now()
+	delay := s.nextRequest.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	s.nextRequest = now.Add(delay + s.requestInterval)
+	s.limiterMu.Unlock()
+
+	if delay > 0 {
+		s.sleep(delay)
+	}
+}
+
+func (s *IFPASyncService) start(leagueID uint) bool {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if s.running[leagueID] {
+		return false
+	}
+	s.running[leagueID] = true
+	return true
+}
+
+func (s *IFPASyncService) finish(leagueID uint) {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	delete(s.running, leagueID)
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+
+	"backend/models"
+)
+
+// ErrIFPADisabled is returned by IFPA lookups when no API key is configured
+var ErrIFPADisabled = errors.New("IFPA integration is disabled")
+
+// ErrOPDBDisabled is returned by OPDB machine lookups when no API token is configured
+var ErrOPDBDisabled = errors.New("OPDB integration is disabled")
+
+// MachineCatalog looks up pinball machines. OPDBService is the production
+// implementation; handlers depend on this interface so tests can swap it out.
+type MachineCatalog interface {
+	GetMachine(opdbID string) (*models.Machine, error)
+	SearchMachines(query string, limit int) ([]MachineSearchResult, error)
+}
+
+// PlayerRegistry looks up players in the IFPA database. IFPAService is the
+// production implementation.
+type PlayerRegistry interface {
+	// Enabled reports whether lookups can be made at all
+	Enabled() bool
+	GetPlayerByIFPANumber(ifpaNumber int) (*IFPAPlayer, error)
+	GetPlayerRank(ifpaNumber int) (int, error)
+	GetPlayerRanking(ifpaNumber int) (*IFPARanking, error)
+}
+
+var (
+	_ MachineCatalog = (*OPDBService)(nil)
+	_ PlayerRegistry = (*IFPAService)(nil)
+)
@@ -2,10 +2,13 @@ package services
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"backend/models"
@@ -14,95 +17,219 @@ import (
 )
 
 type OPDBService struct {
-	db *gorm.DB
+	db          *gorm.DB
+	apiToken    string
+	baseURL     string
+	httpClient  *http.Client
+	cacheTTL    time.Duration
+	notFoundTTL time.Duration
+	logger      *slog.Logger
+
+	// notFound remembers IDs OPDB reported as unknown, and until when
+	notFoundMu sync.Mutex
+	notFound   map[string]time.Time
 }
 
+// ErrMachineNotFound is returned when OPDB has no machine with the requested ID
+var ErrMachineNotFound = errors.New("machine not found in OPDB")
+
 type OPDBMachineResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
-	// Manufacturer string `json:"manufacturer"`
-	Year      int    `json:"year"`
-	IPDBID    int    `json:"ipdb_id"`
-	Type      string `json:"type"`
-	OPDBID    string `json:"opdb_id"`
-	IsPinball bool   `json:"is_pinball"`
-	IsGroup   bool   `json:"is_group"`
-	IsAlias   bool   `json:"is_alias"`
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Manufacturer *OPDBManufacturer `json:"manufacturer"`
+	Year         int               `json:"year"`
+	IPDBID       int               `json:"ipdb_id"`
+	Type         string            `json:"type"`
+	OPDBID       string            `json:"opdb_id"`
+	IsPinball    bool              `json:"is_pinball"`
+	IsGroup      bool              `json:"is_group"`
+	IsAlias      bool              `json:"is_alias"`
+}
+
+// OPDBManufacturer is the manufacturer object OPDB nests in machine records
+type OPDBManufacturer struct {
+	ManufacturerID int    `json:"manufacturer_id"`
+	Name           string `json:"name"`
+	FullName       string `json:"full_name"`
+}
+
+// manufacturerName returns the manufacturer's short name, or "" if OPDB has none
+func (m *OPDBManufacturer) manufacturerName() string {
+	if m == nil {
+		return ""
+	}
+	return m.Name
 }
 
 type OPDBRequest struct {
 	APIToken string `json:"api_token"`
 }
 
-func NewOPDBService(db *gorm.DB) *OPDBService {
-	return &OPDBService{db: db}
+// OPDBConfig configures an OPDBService. Empty fields fall back to the public
+// API, a client with a 10 second timeout, a 24 hour cache, a 1 hour memory of
+// unknown IDs and the default logger. The API token is only needed for
+// machine lookups; search works without one.
+type OPDBConfig struct {
+	APIToken    string
+	BaseURL     string
+	HTTPClient  *http.Client
+	CacheTTL    time.Duration
+	NotFoundTTL time.Duration
+	Logger      *slog.Logger
+}
+
+func NewOPDBService(db *gorm.DB, config OPDBConfig) *OPDBService {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://opdb.org/api"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: time.Second * 10}
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = 24 * time.Hour
+	}
+	if config.NotFoundTTL <= 0 {
+		config.NotFoundTTL = time.Hour
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return &OPDBService{
+		db:          db,
+		apiToken:    config.APIToken,
+		baseURL:     strings.TrimRight(config.BaseURL, "/"),
+		httpClient:  config.HTTPClient,
+		cacheTTL:    config.CacheTTL,
+		notFoundTTL: config.NotFoundTTL,
+		logger:      config.Logger.With("service", "opdb"),
+		notFound:    make(map[string]time.Time),
+	}
 }
 
+// GetMachine returns a machine from the local cache, fetching it from OPDB
+// when it is missing, deleted or older than the cache TTL. If OPDB cannot be
+// reached a stale cached copy is returned rather than failing, but a deleted
+// one is not. IDs OPDB reports as unknown are remembered for the not-found
+// TTL and return ErrMachineNotFound without another request.
 func (s *OPDBService) GetMachine(opdbID string) (*models.Machine, error) {
-	// First, try to get from database
-	var machine models.Machine
-	result := s.db.Where("opdb_id = ?", opdbID).First(&machine)
+	// Soft-deleted rows are included so a refetch restores them instead of
+	// colliding with the unique OPDB ID index
+	var cached models.Machine
+	lookup := s.db.Unscoped().Where("opdb_id = ?", opdbID).Limit(1).Find(&cached)
+	if lookup.Error != nil {
+		return nil, fmt.Errorf("failed to load cached machine: %w", lookup.Error)
+	}
+	found := lookup.RowsAffected > 0
+	live := found && !cached.DeletedAt.Valid
 
-	// If found and recently updated (within last 24 hours), return cached data
-	if result.Error == nil && time.Since(machine.UpdatedAt) < 24*time.Hour {
-		return &machine, nil
+	if live && time.Since(cached.UpdatedAt) < s.cacheTTL {
+		return &cached, nil
+	}
+	if !live && s.knownMissing(opdbID) {
+		return nil, ErrMachineNotFound
 	}
 
-	// If not found or data is stale, fetch from OPDB API
-	apiToken := os.Getenv("OPDB_API_TOKEN")
-	if apiToken == "" {
-		return nil, fmt.Errorf("OPDB_API_TOKEN not set")
+	fetched, err := s.fetchMachine(opdbID)
+	switch {
+	case errors.Is(err, ErrMachineNotFound):
+		s.rememberMissing(opdbID)
+		return nil, ErrMachineNotFound
+	case err != nil && live:
+		s.logger.Warn("serving stale machine, OPDB fetch failed",
+			"opdb_id", opdbID, "cached_at", cached.UpdatedAt, "error", err)
+		return &cached, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if found {
+		fetched.ID = cached.ID
+		fetched.CreatedAt = cached.CreatedAt
+	}
+	fetched.UpdatedAt = time.Now()
+	if err := s.db.Unscoped().Save(fetched).Error; err != nil {
+		return nil, fmt.Errorf("failed to cache machine: %w", err)
+	}
+
+	return fetched, nil
+}
+
+// fetchMachine requests a single machine from OPDB. Errors never contain the
+// API token.
+func (s *OPDBService) fetchMachine(opdbID string) (*models.Machine, error) {
+	if s.apiToken == "" {
+		return nil, ErrOPDBDisabled
 	}
 
-	// Make POST request with URL parameters
-	fmt.Println("Fetching from OPDB API:", opdbID)
 	params := url.Values{}
-	params.Add("api_token", apiToken)
-	url := fmt.Sprintf("https://opdb.org/api/machines/%s?%s", opdbID, params.Encode())
-	fmt.Println("URL:", url)
-	resp, err := http.Get(url)
+	params.Add("api_token", s.apiToken)
+	requestURL := fmt.Sprintf("%s/machines/%s?%s", s.baseURL, url.PathEscape(opdbID), params.Encode())
+
+	started := time.Now()
+	resp, err := s.httpClient.Get(requestURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from OPDB API: %v", err)
+		err = s.redact(err)
+		s.logger.Error("OPDB request failed", "opdb_id", opdbID, "error", err)
+		return nil, fmt.Errorf("failed to fetch from OPDB: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("OPDB API returned status code: %d", resp.StatusCode)
+	s.logger.Info("OPDB machine fetched",
+		"opdb_id", opdbID, "status", resp.StatusCode, "duration", time.Since(started))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrMachineNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("OPDB returned status code: %d", resp.StatusCode)
 	}
 
 	var opdbMachine OPDBMachineResponse
 	if err := json.NewDecoder(resp.Body).Decode(&opdbMachine); err != nil {
-		fmt.Println("OPDB Machine:", opdbMachine)
-		fmt.Println("Error decoding OPDB response:", err)
-		fmt.Println("Response body:", resp.Body)
-		return nil, fmt.Errorf("failed to decode OPDB response: %v", err)
-	}
-	// Convert OPDB response to our model
-	machine = models.Machine{
-		OPDBID: opdbMachine.OPDBID,
-		Name:   opdbMachine.Name,
-		// Manufacturer:  opdbMachine.Manufacturer,
-		Year:      opdbMachine.Year,
-		IPDBID:    opdbMachine.IPDBID,
-		Type:      opdbMachine.Type,
-		IsPinball: opdbMachine.IsPinball,
-		IsGroup:   opdbMachine.IsGroup,
-		IsAlias:   opdbMachine.IsAlias,
-		UpdatedAt: time.Now(),
-	}
-
-	// Save to database (create or update)
-	if result.Error == nil {
-		// Update existing record
-		if err := s.db.Model(&machine).Updates(machine).Error; err != nil {
-			return nil, fmt.Errorf("failed to update machine in database: %v", err)
-		}
-	} else {
-		// Create new record
-		if err := s.db.Create(&machine).Error; err != nil {
-			return nil, fmt.Errorf("failed to create machine in database: %v", err)
-		}
-	}
-
-	return &machine, nil
+		s.logger.Error("OPDB response could not be decoded", "opdb_id", opdbID, "error", err)
+		return nil, fmt.Errorf("failed to decode OPDB response: %w", err)
+	}
+
+	return &models.Machine{
+		OPDBID:       opdbMachine.OPDBID,
+		Name:         opdbMachine.Name,
+		Manufacturer: opdbMachine.Manufacturer.manufacturerName(),
+		Year:         opdbMachine.Year,
+		IPDBID:       opdbMachine.IPDBID,
+		Type:         opdbMachine.Type,
+		IsPinball:    opdbMachine.IsPinball,
+		IsGroup:      opdbMachine.IsGroup,
+		IsAlias:      opdbMachine.IsAlias,
+	}, nil
+}
+
+// knownMissing reports whether OPDB recently said it has no such machine
+func (s *OPDBService) knownMissing(opdbID string) bool {
+	s.notFoundMu.Lock()
+	defer s.notFoundMu.Unlock()
+
+	until, ok := s.notFound[opdbID]
+	if ok && time.Now().After(until) {
+		delete(s.notFound, opdbID)
+		return false
+	}
+	return ok
+}
+
+func (s *OPDBService) rememberMissing(opdbID string) {
+	s.notFoundMu.Lock()
+	defer s.notFoundMu.Unlock()
+
+	s.notFound[opdbID] = time.Now().Add(s.notFoundTTL)
+}
+
+// redact strips the API token from an error. Transport errors quote the
+// request URL, which carries the token as a query parameter.
+func (s *OPDBService) redact(err error) error {
+	if s.apiToken == "" {
+		return err
+	}
+	message := strings.ReplaceAll(err.Error(), url.QueryEscape(s.apiToken), "REDACTED")
+	return errors.New(strings.ReplaceAll(message, s.apiToken, "REDACTED"))
 }
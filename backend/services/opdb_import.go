@@ -0,0 +1,171 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// OPDBExportEntry is a record from an OPDB export file. Machine exports and
+// group exports share these fields; groups simply leave the machine details
+// empty. Aliases may appear as entries of their own or nested under the
+// machine they belong to.
+type OPDBExportEntry struct {
+	OPDBID          string            `json:"opdb_id"`
+	Name            string            `json:"name"`
+	IPDBID          int               `json:"ipdb_id"`
+	ManufactureDate string            `json:"manufacture_date"`
+	Manufacturer    *OPDBManufacturer `json:"manufacturer"`
+	Type            string            `json:"type"`
+	Aliases         []OPDBExportEntry `json:"aliases"`
+}
+
+// OPDBImportResult counts what an import did to the machine table
+type OPDBImportResult struct {
+	Added     int
+	Updated   int
+	Unchanged int
+}
+
+// opdbImportBatchSize keeps bulk statements under SQLite's variable limit
+const opdbImportBatchSize = 500
+
+// ParseOPDBExport reads the JSON array of an OPDB export file, flattening
+// nested aliases into the list
+func ParseOPDBExport(r io.Reader) ([]OPDBExportEntry, error) {
+	var entries []OPDBExportEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode OPDB export: %w", err)
+	}
+
+	flattened := make([]OPDBExportEntry, 0, len(entries))
+	for _, entry := range entries {
+		flattened = append(flattened, entry)
+		flattened = append(flattened, entry.Aliases...)
+	}
+	return flattened, nil
+}
+
+// machine converts an export entry to our model. Whether the record is a
+// group, machine or alias is read from the shape of its OPDB ID: groups are
+// "G…", machines "G…-M…" and aliases "G…-M…-A…".
+func (e OPDBExportEntry) machine() models.Machine {
+	parts := strings.Split(e.OPDBID, "-")
+
+	year := 0
+	if len(e.ManufactureDate) >= 4 {
+		year, _ = strconv.Atoi(e.ManufactureDate[:4])
+	}
+
+	return models.Machine{
+		OPDBID:       e.OPDBID,
+		Name:         e.Name,
+		Manufacturer: e.Manufacturer.manufacturerName(),
+		Year:         year,
+		IPDBID:       e.IPDBID,
+		Type:         e.Type,
+		IsPinball:    true, // OPDB only catalogues pinball machines
+		IsGroup:      len(parts) == 1,
+		IsAlias:      len(parts) > 2,
+	}
+}
+
+// sameCatalogData reports whether two machines hold the same OPDB data,
+// ignoring bookkeeping columns
+func sameCatalogData(a, b models.Machine) bool {
+	return a.Name == b.Name &&
+		a.Manufacturer == b.Manufacturer &&
+		a.Year == b.Year &&
+		a.IPDBID == b.IPDBID &&
+		a.Type == b.Type &&
+		a.IsPinball == b.IsPinball &&
+		a.IsGroup == b.IsGroup &&
+		a.IsAlias == b.IsAlias
+}
+
+// ImportOPDBExport upserts export entries into the machine table in a single
+// transaction. Every imported row, changed or not, has its UpdatedAt bumped
+// so OPDBService.GetMachine treats it as freshly cached. Soft-deleted
+// machines in the export are restored. When an ID appears more than once the
+// last entry wins.
+func ImportOPDBExport(db *gorm.DB, entries []OPDBExportEntry) (OPDBImportResult, error) {
+	var result OPDBImportResult
+
+	incoming := make(map[string]models.Machine, len(entries))
+	var ids []string
+	for _, entry := range entries {
+		if entry.OPDBID == "" {
+			continue
+		}
+		if _, seen := incoming[entry.OPDBID]; !seen {
+			ids = append(ids, entry.OPDBID)
+		}
+		incoming[entry.OPDBID] = entry.machine()
+	}
+
+	now := time.Now()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		existing := make(map[string]models.Machine, len(ids))
+		for start := 0; start < len(ids); start += opdbImportBatchSize {
+			end := min(start+opdbImportBatchSize, len(ids))
+			var machines []models.Machine
+			if err := tx.Unscoped().Where("opdb_id IN ?", ids[start:end]).Find(&machines).Error; err != nil {
+				return fmt.Errorf("failed to load existing machines: %w", err)
+			}
+			for _, machine := range machines {
+				existing[machine.OPDBID] = machine
+			}
+		}
+
+		var toCreate []models.Machine
+		var unchangedIDs []uint
+		for _, opdbID := range ids {
+			machine := incoming[opdbID]
+			current, found := existing[opdbID]
+			switch {
+			case !found:
+				machine.CreatedAt = now
+				machine.UpdatedAt = now
+				toCreate = append(toCreate, machine)
+				result.Added++
+			case current.DeletedAt.Valid || !sameCatalogData(current, machine):
+				machine.ID = current.ID
+				machine.CreatedAt = current.CreatedAt
+				machine.UpdatedAt = now
+				// Select("*") writes zero values too and clears DeletedAt
+				if err := tx.Unscoped().Select("*").Updates(&machine).Error; err != nil {
+					return fmt.Errorf("failed to update machine %s: %w", opdbID, err)
+				}
+				result.Updated++
+			default:
+				unchangedIDs = append(unchangedIDs, current.ID)
+				result.Unchanged++
+			}
+		}
+
+		if len(toCreate) > 0 {
+			if err := tx.CreateInBatches(toCreate, opdbImportBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to create machines: %w", err)
+			}
+		}
+		for start := 0; start < len(unchangedIDs); start += opdbImportBatchSize {
+			end := min(start+opdbImportBatchSize, len(unchangedIDs))
+			if err := tx.Model(&models.Machine{}).Where("id IN ?", unchangedIDs[start:end]).Update("updated_at", now).Error; err != nil {
+				return fmt.Errorf("failed to refresh unchanged machines: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return OPDBImportResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"backend/models"
+)
+
+// MachineSearchResult is a machine matching a search, from OPDB, the local
+// machine table, or both
+type MachineSearchResult struct {
+	OPDBID        string `json:"opdbID"`
+	Name          string `json:"name"`
+	Supplementary string `json:"supplementary,omitempty"` // Manufacturer and year, as OPDB displays them
+	MachineID     *uint  `json:"machineID"`               // Set when the machine is already cached locally
+	Source        string `json:"source"`                  // "local", "opdb" or "both"
+	Relevance     int    `json:"relevance"`
+}
+
+// OPDBTypeaheadResult is a single suggestion from OPDB's typeahead search
+type OPDBTypeaheadResult struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Supplementary string `json:"supplementary"`
+}
+
+// SearchMachines searches OPDB's typeahead API and the local machine table
+// and merges the results, most relevant first. Local results are still
+// returned if OPDB cannot be reached; the OPDB error is returned alongside
+// them so the caller can log it.
+func (s *OPDBService) SearchMachines(query string, limit int) ([]MachineSearchResult, error) {
+	var local []models.Machine
+	pattern := "%" + EscapeLike(strings.ToLower(query)) + "%"
+	if err := s.db.Where(`LOWER(name) LIKE ? ESCAPE '\'`, pattern).Limit(limit).Find(&local).Error; err != nil {
+		return nil, fmt.Errorf("failed to search local machines: %w", err)
+	}
+
+	remote, opdbErr := s.typeahead(query)
+
+	return RankMachineSearch(query, local, remote, limit), opdbErr
+}
+
+// typeahead calls OPDB's public typeahead search
+func (s *OPDBService) typeahead(query string) ([]OPDBTypeaheadResult, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("include_aliases", "1")
+	params.Add("include_groups", "0")
+
+	resp, err := s.httpClient.Get(fmt.Sprintf("%s/search/typeahead?%s", s.baseURL, params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search OPDB: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OPDB search returned status code: %d", resp.StatusCode)
+	}
+
+	var results []OPDBTypeaheadResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode OPDB search response: %w", err)
+	}
+	return results, nil
+}
+
+// RankMachineSearch merges local and OPDB results for the same machine and
+// orders them by how closely the name matches the query: exact matches, then
+// names starting with the query, then names with a word starting with it,
+// then names containing it, then anything else OPDB matched. Ties go to
+// machines already cached locally, then to the name.
+func RankMachineSearch(query string, local []models.Machine, remote []OPDBTypeaheadResult, limit int) []MachineSearchResult {
+	merged := make(map[string]*MachineSearchResult)
+	var order []string
+
+	add := func(opdbID, name string) *MachineSearchResult {
+		result, ok := merged[opdbID]
+		if !ok {
+			result = &MachineSearchResult{OPDBID: opdbID, Name: name}
+			merged[opdbID] = result
+			order = append(order, opdbID)
+		}
+		return result
+	}
+
+	for i := range local {
+		result := add(local[i].OPDBID, local[i].Name)
+		result.MachineID = &local[i].ID
+		result.Source = "local"
+	}
+	for _, suggestion := range remote {
+		result := add(suggestion.ID, suggestion.Name)
+		result.Supplementary = suggestion.Supplementary
+		if result.MachineID != nil {
+			result.Source = "both"
+		} else {
+			result.Source = "opdb"
+		}
+	}
+
+	results := make([]MachineSearchResult, 0, len(order))
+	for _, opdbID := range order {
+		result := merged[opdbID]
+		result.Relevance = nameRelevance(query, result.Name)
+		results = append(results, *result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Relevance != b.Relevance {
+			return a.Relevance > b.Relevance
+		}
+		if (a.MachineID != nil) != (b.MachineID != nil) {
+			return a.MachineID != nil
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// nameRelevance scores how closely a machine name matches the query
+func nameRelevance(query, name string) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	name = strings.ToLower(name)
+
+	switch {
+	case name == query:
+		return 100
+	case strings.HasPrefix(name, query):
+		return 75
+	case strings.Contains(" "+name, " "+query):
+		return 50
+	case strings.Contains(name, query):
+		return 25
+	default:
+		return 10
+	}
+}
+
+// EscapeLike escapes the wildcard characters of a LIKE pattern
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"backend/models"
+)
+
+// newTestDB opens an empty database in the test's temporary directory
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Machine{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// newTypeaheadServer stands in for OPDB's typeahead search, answering every
+// query with the given suggestions
+func newTypeaheadServer(t *testing.T, suggestions []OPDBTypeaheadResult) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/typeahead" {
+			t.Errorf("unexpected OPDB request path %q", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("q") == "" {
+			t.Errorf("typeahead request without a query: %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(suggestions)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func cacheMachines(t *testing.T, db *gorm.DB, machines ...models.Machine) {
+	t.Helper()
+	for i := range machines {
+		if err := db.Create(&machines[i]).Error; err != nil {
+			t.Fatalf("cache machine: %v", err)
+		}
+	}
+}
+
+func TestSearchMachinesMergesOPDBAndCachedMachines(t *testing.T) {
+	db := newTestDB(t)
+	cacheMachines(t, db,
+		models.Machine{OPDBID: "G1-M1", Name: "Attack from Mars"},
+		models.Machine{OPDBID: "G4-M4", Name: "Medieval Madness"},
+	)
+	server := newTypeaheadServer(t, []OPDBTypeaheadResult{
+		{ID: "G1-M1", Name: "Attack from Mars", Supplementary: "Bally, 1995"},
+		{ID: "G2-M2", Name: "Attack from Mars (Remake)", Supplementary: "Chicago Gaming, 2017"},
+	})
+	service := NewOPDBService(db, OPDBConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+
+	results, err := service.SearchMachines("attack", 20)
+	if err != nil {
+		t.Fatalf("SearchMachines: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2: %+v", len(results), results)
+	}
+	cached, remote := results[0], results[1]
+	if cached.OPDBID != "G1-M1" || cached.Source != "both" || cached.MachineID == nil || cached.Supplementary != "Bally, 1995" {
+		t.Errorf("cached machine not merged with its OPDB result: %+v", cached)
+	}
+	if remote.OPDBID != "G2-M2" || remote.Source != "opdb" || remote.MachineID != nil {
+		t.Errorf("OPDB-only machine reported wrongly: %+v", remote)
+	}
+}
+
+func TestSearchMachinesFallsBackToLocalWhenOPDBUnreachable(t *testing.T) {
+	db := newTestDB(t)
+	cacheMachines(t, db, models.Machine{OPDBID: "G1-M1", Name: "Attack from Mars"})
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	service := NewOPDBService(db, OPDBConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+
+	results, err := service.SearchMachines("attack", 20)
+	if err == nil {
+		t.Error("expected the OPDB error to be returned")
+	}
+	if len(results) != 1 || results[0].OPDBID != "G1-M1" || results[0].Source != "local" {
+		t.Errorf("expected the cached machine alone, got %+v", results)
+	}
+}
+
+func TestSearchMachinesFallsBackToLocalOnOPDBError(t *testing.T) {
+	db := newTestDB(t)
+	cacheMachines(t, db, models.Machine{OPDBID: "G1-M1", Name: "Attack from Mars"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	service := NewOPDBService(db, OPDBConfig{BaseURL: server.URL, HTTPClient: server.Client()})
+
+	results, err := service.SearchMachines("attack", 20)
+	if err == nil {
+		t.Error("expected the OPDB error to be returned")
+	}
+	if len(results) != 1 || results[0].Source != "local" {
+		t.Errorf("expected the cached machine alone, got %+v", results)
+	}
+}
+
+func TestRankMachineSearchDeduplicatesByOPDBID(t *testing.T) {
+	local := []models.Machine{{Model: gorm.Model{ID: 7}, OPDBID: "G1-M1", Name: "Twilight Zone"}}
+	remote := []OPDBTypeaheadResult{
+		{ID: "G1-M1", Name: "Twilight Zone", Supplementary: "Bally, 1993"},
+		{ID: "G1-M1", Name: "Twilight Zone", Supplementary: "Bally, 1993"},
+	}
+
+	results := RankMachineSearch("twilight", local, remote, 0)
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+	if results[0].Source != "both" || results[0].MachineID == nil || *results[0].MachineID != 7 {
+		t.Errorf("duplicate not merged with the cached machine: %+v", results[0])
+	}
+}
+
+func TestRankMachineSearchOrdersByRelevance(t *testing.T) {
+	local := []models.Machine{
+		{Model: gorm.Model{ID: 1}, OPDBID: "L-SUB", Name: "Lemars"},
+		{Model: gorm.Model{ID: 2}, OPDBID: "L-WORD", Name: "Attack from Mars"},
+	}
+	remote := []OPDBTypeaheadResult{
+		{ID: "R-FUZZY", Name: "Maze Runner"},
+		{ID: "R-WORD", Name: "Escape to Mars"},
+		{ID: "R-PREFIX", Name: "Mars Trek"},
+		{ID: "R-EXACT", Name: "Mars"},
+	}
+
+	results := RankMachineSearch("mars", local, remote, 0)
+
+	want := []struct {
+		opdbID    string
+		relevance int
+	}{
+		{"R-EXACT", 100},
+		{"R-PREFIX", 75},
+		{"L-WORD", 50}, // Cached machines win ties
+		{"R-WORD", 50},
+		{"L-SUB", 25},
+		{"R-FUZZY", 10},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, w := range want {
+		if results[i].OPDBID != w.opdbID || results[i].Relevance != w.relevance {
+			t.Errorf("result %d = %s (%d), want %s (%d)", i, results[i].OPDBID, results[i].Relevance, w.opdbID, w.relevance)
+		}
+	}
+
+	if limited := RankMachineSearch("mars", local, remote, 2); len(limited) != 2 || limited[1].OPDBID != "R-PREFIX" {
+		t.Errorf("limit should keep the two most relevant results, got %+v", limited)
+	}
+}
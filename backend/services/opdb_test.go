@@ -0,0 +1,91 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+func TestOPDBServiceUsesConfiguredBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/machines/G5pe4-MePZv" {
+			t.Errorf("request path = %q, want /api/machines/G5pe4-MePZv", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("api_token"); got != "test-token" {
+			t.Errorf("api_token = %q", got)
+		}
+		w.Write([]byte(`{"opdb_id":"G5pe4-MePZv","name":"Medieval Madness","manufacturer":{"name":"Williams"},"year":1997,"is_pinball":true}`))
+	}))
+	defer server.Close()
+	service := NewOPDBService(newTestDB(t), OPDBConfig{APIToken: "test-token", BaseURL: server.URL + "/api/", HTTPClient: server.Client()})
+
+	machine, err := service.GetMachine("G5pe4-MePZv")
+	if err != nil {
+		t.Fatalf("GetMachine: %v", err)
+	}
+	if machine.OPDBID != "G5pe4-MePZv" || machine.Name != "Medieval Madness" || machine.Manufacturer != "Williams" {
+		t.Errorf("unexpected machine %+v", machine)
+	}
+}
+
+func TestGetMachineServesStaleCopyWhenOPDBUnreachable(t *testing.T) {
+	db := newTestDB(t)
+	stale := models.Machine{OPDBID: "G1-M1", Name: "Attack from Mars"}
+	db.Create(&stale)
+	db.Model(&stale).UpdateColumn("updated_at", time.Now().Add(-48*time.Hour))
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	service := NewOPDBService(db, OPDBConfig{APIToken: "test-token", BaseURL: server.URL, HTTPClient: server.Client()})
+
+	machine, err := service.GetMachine("G1-M1")
+	if err != nil {
+		t.Fatalf("GetMachine: %v", err)
+	}
+	if machine.ID != stale.ID {
+		t.Errorf("expected the stale cached machine, got %+v", machine)
+	}
+}
+
+func TestGetMachineTreatsDeletedCopyAsMissing(t *testing.T) {
+	db := newTestDB(t)
+	deleted := models.Machine{OPDBID: "G1-M1", Name: "Attack from Mars"}
+	db.Create(&deleted)
+	db.Delete(&deleted)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	service := NewOPDBService(db, OPDBConfig{APIToken: "test-token", BaseURL: server.URL, HTTPClient: server.Client()})
+
+	if machine, err := service.GetMachine("G1-M1"); err == nil {
+		t.Errorf("expected an error while OPDB is unreachable, got deleted machine %+v", machine)
+	}
+}
+
+func TestGetMachineRestoresDeletedCopy(t *testing.T) {
+	db := newTestDB(t)
+	deleted := models.Machine{OPDBID: "G1-M1", Name: "Attack from Mars"}
+	db.Create(&deleted)
+	db.Delete(&deleted)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"opdb_id":"G1-M1","name":"Attack from Mars","year":1995,"is_pinball":true}`))
+	}))
+	defer server.Close()
+	service := NewOPDBService(db, OPDBConfig{APIToken: "test-token", BaseURL: server.URL, HTTPClient: server.Client()})
+
+	machine, err := service.GetMachine("G1-M1")
+	if err != nil {
+		t.Fatalf("GetMachine: %v", err)
+	}
+	if machine.ID != deleted.ID || machine.Year != 1995 {
+		t.Errorf("expected the deleted row to be refreshed in place, got %+v", machine)
+	}
+	var restored models.Machine
+	if err := db.First(&restored, deleted.ID).Error; err != nil {
+		t.Errorf("machine still deleted after refetch: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"backend/models"
+)
+
+// BuildSchedule assigns each group a different machine in every round so that
+// no two groups share a machine in the same round and no group plays the same
+// machine twice. The machine bank is shuffled using the event seed and each
+// group then rotates through it, one machine further along per round.
+func BuildSchedule(groups []models.Group, machines []models.Machine, gamesPerGroup int, seed int64) ([]models.ScheduledGame, error) {
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("the event has no groups")
+	}
+	if len(machines) < len(groups) {
+		return nil, fmt.Errorf("%d groups need at least %d machines, but the event has %d", len(groups), len(groups), len(machines))
+	}
+	if gamesPerGroup > len(machines) {
+		return nil, fmt.Errorf("each group cannot play %d distinct machines with only %d machines in the event", gamesPerGroup, len(machines))
+	}
+
+	bank := append([]models.Machine{}, machines...)
+	sort.Slice(bank, func(i, j int) bool { return bank[i].ID < bank[j].ID })
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(bank), func(i, j int) { bank[i], bank[j] = bank[j], bank[i] })
+
+	schedule := make([]models.ScheduledGame, 0, len(groups)*gamesPerGroup)
+	for round := 0; round < gamesPerGroup; round++ {
+		for i, group := range groups {
+			machine := bank[(i+round)%len(bank)]
+			schedule = append(schedule, models.ScheduledGame{
+				EventID:   group.EventID,
+				Round:     round + 1,
+				GroupID:   group.ID,
+				MachineID: machine.ID,
+			})
+		}
+	}
+
+	return schedule, nil
+}
+
+// DropMachine moves every unplayed game scheduled on a machine onto the
+// remaining machines, changing the schedule in place. Each game moves to a
+// machine that is free in the same round, preferring one the group is not
+// already scheduled to play; if every machine is busy the game moves to a
+// later round in which the group is not already playing, adding rounds at the
+// end if needed. Games already recorded are left alone. It returns the
+// indices of the games that were moved.
+func DropMachine(schedule []models.ScheduledGame, machineID uint, machines []models.Machine) ([]int, error) {
+	var toMove []int
+	for i, slot := range schedule {
+		if slot.MachineID == machineID && slot.GameID == nil {
+			toMove = append(toMove, i)
+		}
+	}
+	if len(toMove) == 0 {
+		return nil, nil
+	}
+
+	bank := make([]uint, 0, len(machines))
+	for _, machine := range machines {
+		if machine.ID != machineID {
+			bank = append(bank, machine.ID)
+		}
+	}
+	if len(bank) == 0 {
+		return nil, fmt.Errorf("no other machines are available to take the games scheduled on machine %d", machineID)
+	}
+	sort.Slice(bank, func(i, j int) bool { return bank[i] < bank[j] })
+
+	machinesInRound := make(map[int]map[uint]bool)
+	groupsInRound := make(map[int]map[uint]bool)
+	groupMachines := make(map[uint]map[uint]bool)
+	mark := func(slot models.ScheduledGame) {
+		if machinesInRound[slot.Round] == nil {
+			machinesInRound[slot.Round] = make(map[uint]bool)
+			groupsInRound[slot.Round] = make(map[uint]bool)
+		}
+		machinesInRound[slot.Round][slot.MachineID] = true
+		groupsInRound[slot.Round][slot.GroupID] = true
+		if groupMachines[slot.GroupID] == nil {
+			groupMachines[slot.GroupID] = make(map[uint]bool)
+		}
+		groupMachines[slot.GroupID][slot.MachineID] = true
+	}
+	moving := make(map[int]bool, len(toMove))
+	for _, i := range toMove {
+		moving[i] = true
+	}
+	for i, slot := range schedule {
+		if !moving[i] {
+			mark(slot)
+		}
+	}
+
+	// freeMachine picks a machine not in use in the round, preferring one the
+	// group has not been scheduled on. It returns 0 if every machine is busy.
+	freeMachine := func(round int, groupID uint) uint {
+		var fallback uint
+		for _, id := range bank {
+			if machinesInRound[round][id] {
+				continue
+			}
+			if !groupMachines[groupID][id] {
+				return id
+			}
+			if fallback == 0 {
+				fallback = id
+			}
+		}
+		return fallback
+	}
+
+	sort.Slice(toMove, func(a, b int) bool {
+		if schedule[toMove[a]].Round != schedule[toMove[b]].Round {
+			return schedule[toMove[a]].Round < schedule[toMove[b]].Round
+		}
+		return schedule[toMove[a]].GroupID < schedule[toMove[b]].GroupID
+	})
+	for _, i := range toMove {
+		slot := &schedule[i]
+		if id := freeMachine(slot.Round, slot.GroupID); id != 0 {
+			slot.MachineID = id
+		} else {
+			// Rounds past the end of the schedule are empty, so this always finds a slot
+			for round := slot.Round + 1; ; round++ {
+				if groupsInRound[round][slot.GroupID] {
+					continue
+				}
+				if id := freeMachine(round, slot.GroupID); id != 0 {
+					slot.Round = round
+					slot.MachineID = id
+					break
+				}
+			}
+		}
+		mark(*slot)
+	}
+
+	return toMove, nil
+}
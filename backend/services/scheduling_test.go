@@ -0,0 +1,216 @@
+package services
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+func testGroups(count int) []models.Group {
+	groups := make([]models.Group, count)
+	for i := range groups {
+		groups[i] = models.Group{Model: gorm.Model{ID: uint(i + 1)}, EventID: 1, Number: i + 1}
+	}
+	return groups
+}
+
+func testMachines(ids ...uint) []models.Machine {
+	machines := make([]models.Machine, len(ids))
+	for i, id := range ids {
+		machines[i] = models.Machine{Model: gorm.Model{ID: id}}
+	}
+	return machines
+}
+
+// checkSchedule fails the test if two groups share a machine in a round, a
+// group plays twice in a round or a group plays the same machine twice
+func checkSchedule(t *testing.T, schedule []models.ScheduledGame) {
+	t.Helper()
+	type roundKey struct {
+		round int
+		id    uint
+	}
+	machineInRound := make(map[roundKey]bool)
+	groupInRound := make(map[roundKey]bool)
+	groupMachine := make(map[[2]uint]bool)
+	for _, slot := range schedule {
+		if machineInRound[roundKey{slot.Round, slot.MachineID}] {
+			t.Errorf("machine %d is used twice in round %d", slot.MachineID, slot.Round)
+		}
+		if groupInRound[roundKey{slot.Round, slot.GroupID}] {
+			t.Errorf("group %d plays twice in round %d", slot.GroupID, slot.Round)
+		}
+		if groupMachine[[2]uint{slot.GroupID, slot.MachineID}] {
+			t.Errorf("group %d plays machine %d twice", slot.GroupID, slot.MachineID)
+		}
+		machineInRound[roundKey{slot.Round, slot.MachineID}] = true
+		groupInRound[roundKey{slot.Round, slot.GroupID}] = true
+		groupMachine[[2]uint{slot.GroupID, slot.MachineID}] = true
+	}
+}
+
+func TestBuildSchedule(t *testing.T) {
+	tests := []struct {
+		name          string
+		groups        int
+		machines      int
+		gamesPerGroup int
+	}{
+		{"as many machines as groups", 3, 3, 3},
+		{"spare machines", 3, 5, 4},
+		{"single group", 1, 4, 4},
+		{"fewer games than machines", 4, 6, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]uint, tt.machines)
+			for i := range ids {
+				ids[i] = uint(i + 1)
+			}
+			schedule, err := BuildSchedule(testGroups(tt.groups), testMachines(ids...), tt.gamesPerGroup, 7)
+			if err != nil {
+				t.Fatalf("BuildSchedule: %v", err)
+			}
+			if len(schedule) != tt.groups*tt.gamesPerGroup {
+				t.Fatalf("got %d games, want %d", len(schedule), tt.groups*tt.gamesPerGroup)
+			}
+			checkSchedule(t, schedule)
+			for _, slot := range schedule {
+				if slot.EventID != 1 || slot.Round < 1 || slot.Round > tt.gamesPerGroup {
+					t.Errorf("unexpected slot %+v", slot)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildScheduleIsRepeatable(t *testing.T) {
+	first, _ := BuildSchedule(testGroups(3), testMachines(4, 2, 9, 1), 3, 99)
+	// The machine bank is sorted before shuffling, so its order doesn't matter
+	second, _ := BuildSchedule(testGroups(3), testMachines(1, 2, 4, 9), 3, 99)
+	for i := range first {
+		if first[i].Round != second[i].Round || first[i].GroupID != second[i].GroupID || first[i].MachineID != second[i].MachineID {
+			t.Fatalf("slot %d differs: %+v vs %+v", i, first[i], second[i])
+		}
+	}
+}
+
+func TestBuildScheduleErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		groups        int
+		machines      []uint
+		gamesPerGroup int
+	}{
+		{"no groups", 0, []uint{1, 2}, 1},
+		{"fewer machines than groups", 3, []uint{1, 2}, 1},
+		{"more games than machines", 2, []uint{1, 2}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := BuildSchedule(testGroups(tt.groups), testMachines(tt.machines...), tt.gamesPerGroup, 1); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestDropMachineUsesFreeMachines(t *testing.T) {
+	machines := testMachines(1, 2, 3, 4)
+	schedule, err := BuildSchedule(testGroups(2), machines, 2, 3)
+	if err != nil {
+		t.Fatalf("BuildSchedule: %v", err)
+	}
+	dropped := schedule[0].MachineID
+
+	moved, err := DropMachine(schedule, dropped, machines)
+	if err != nil {
+		t.Fatalf("DropMachine: %v", err)
+	}
+	if len(moved) == 0 {
+		t.Fatal("expected games to move")
+	}
+	for _, slot := range schedule {
+		if slot.MachineID == dropped {
+			t.Errorf("game still scheduled on the dropped machine: %+v", slot)
+		}
+		if slot.Round > 2 {
+			t.Errorf("game moved to round %d although machines were free", slot.Round)
+		}
+	}
+	checkSchedule(t, schedule)
+}
+
+func TestDropMachineLeavesPlayedGames(t *testing.T) {
+	gameID := uint(10)
+	schedule := []models.ScheduledGame{
+		{Round: 1, GroupID: 1, MachineID: 1, GameID: &gameID},
+		{Round: 2, GroupID: 1, MachineID: 2},
+		{Round: 1, GroupID: 2, MachineID: 2},
+		{Round: 2, GroupID: 2, MachineID: 1},
+	}
+
+	moved, err := DropMachine(schedule, 1, testMachines(1, 2, 3))
+	if err != nil {
+		t.Fatalf("DropMachine: %v", err)
+	}
+	if len(moved) != 1 || moved[0] != 3 {
+		t.Errorf("moved = %v, want [3]", moved)
+	}
+	if schedule[0].MachineID != 1 {
+		t.Errorf("played game was moved: %+v", schedule[0])
+	}
+	if schedule[3].MachineID != 3 || schedule[3].Round != 2 {
+		t.Errorf("unplayed game = %+v, want machine 3 in round 2", schedule[3])
+	}
+}
+
+func TestDropMachineAddsRoundsWhenEveryMachineIsBusy(t *testing.T) {
+	schedule := []models.ScheduledGame{
+		{Round: 1, GroupID: 1, MachineID: 1},
+		{Round: 1, GroupID: 2, MachineID: 2},
+		{Round: 2, GroupID: 1, MachineID: 2},
+		{Round: 2, GroupID: 2, MachineID: 1},
+	}
+
+	if _, err := DropMachine(schedule, 1, testMachines(1, 2)); err != nil {
+		t.Fatalf("DropMachine: %v", err)
+	}
+	for _, slot := range schedule {
+		if slot.MachineID != 2 {
+			t.Errorf("game not moved onto the remaining machine: %+v", slot)
+		}
+	}
+	rounds := make(map[int]bool)
+	for _, slot := range schedule {
+		if rounds[slot.Round] {
+			t.Errorf("round %d uses machine 2 twice", slot.Round)
+		}
+		rounds[slot.Round] = true
+	}
+	if len(rounds) != 4 {
+		t.Errorf("expected the games to spread over 4 rounds, got %v", rounds)
+	}
+}
+
+func TestDropMachineWithoutSpareMachine(t *testing.T) {
+	schedule := []models.ScheduledGame{{Round: 1, GroupID: 1, MachineID: 1}}
+
+	if _, err := DropMachine(schedule, 1, testMachines(1)); err == nil {
+		t.Error("expected an error when no other machine is left")
+	}
+	if schedule[0].MachineID != 1 {
+		t.Errorf("schedule changed despite the error: %+v", schedule[0])
+	}
+}
+
+func TestDropMachineNotScheduled(t *testing.T) {
+	schedule := []models.ScheduledGame{{Round: 1, GroupID: 1, MachineID: 1}}
+
+	moved, err := DropMachine(schedule, 5, testMachines(1))
+	if err != nil || moved != nil {
+		t.Errorf("DropMachine = %v, %v; want nothing moved", moved, err)
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// DefaultPointDistribution returns the points awarded per finishing position,
+// keyed by the number of players in the game. It is used for any group size
+// that a season's own point distribution does not cover.
+func DefaultPointDistribution() models.PointDistributionMap {
+	return models.PointDistributionMap{
+		"2": {7, 1},
+		"3": {7, 4, 1},
+		"4": {7, 5, 3, 1},
+	}
+}
+
+// ValidatePointDistribution checks that every entry is keyed by a positive
+// player count and awards points to no more positions than there are players
+func ValidatePointDistribution(distribution models.PointDistributionMap) error {
+	for key, points := range distribution {
+		playerCount, err := strconv.Atoi(key)
+		if err != nil || playerCount < 1 {
+			return fmt.Errorf("invalid player count %q in point distribution", key)
+		}
+		if len(points) > playerCount {
+			return fmt.Errorf("point distribution for %d players has %d positions", playerCount, len(points))
+		}
+		for _, p := range points {
+			if p < 0 {
+				return fmt.Errorf("point distribution for %d players contains negative points", playerCount)
+			}
+		}
+	}
+	return nil
+}
+
+// PointsForPosition returns the points awarded for finishing in the given
+// position (1-based) of a game with groupSize players
+func PointsForPosition(distribution models.PointDistributionMap, groupSize, position int) float64 {
+	key := strconv.Itoa(groupSize)
+	points, ok := distribution[key]
+	if !ok {
+		points = DefaultPointDistribution()[key]
+	}
+	if position < 1 || position > len(points) {
+		return 0
+	}
+	return points[position-1]
+}
+
+// PlayerEventPoints is a player's points total for a single event
+type PlayerEventPoints struct {
+	PlayerID    uint    `json:"playerID"`
+	PlayerName  string  `json:"playerName"`
+	Points      float64 `json:"points"`
+	GamesPlayed int     `json:"gamesPlayed"`
+	Place       int     `json:"place"`
+}
+
+// ComputeEventPoints converts the finishing positions of every game into
+// points and totals them per player. The result is ordered by points, highest
+// first, with tied players sharing the same place.
+func ComputeEventPoints(distribution models.PointDistributionMap, games []models.Game) []PlayerEventPoints {
+	totals := make(map[uint]*PlayerEventPoints)
+	for _, game := range games {
+		groupSize := len(game.Results)
+		for _, result := range game.Results {
+			entry, ok := totals[result.PlayerID]
+			if !ok {
+				entry = &PlayerEventPoints{
+					PlayerID:   result.PlayerID,
+					PlayerName: result.Player.Name,
+				}
+				totals[result.PlayerID] = entry
+			}
+			entry.Points += PointsForPosition(distribution, groupSize, result.Position)
+			entry.GamesPlayed++
+		}
+	}
+
+	points := make([]PlayerEventPoints, 0, len(totals))
+	for _, entry := range totals {
+		points = append(points, *entry)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Points != points[j].Points {
+			return points[i].Points > points[j].Points
+		}
+		return points[i].PlayerID < points[j].PlayerID
+	})
+	for i := range points {
+		if i > 0 && points[i].Points == points[i-1].Points {
+			points[i].Place = points[i-1].Place
+		} else {
+			points[i].Place = i + 1
+		}
+	}
+
+	return points
+}
+
+type ScoringService struct {
+	db *gorm.DB
+}
+
+func NewScoringService(db *gorm.DB) *ScoringService {
+	return &ScoringService{db: db}
+}
+
+// EventPoints returns the points earned by each player in an event. Completed
+// events return the points stored when they were completed; other events are
+// scored from the games recorded so far.
+func (s *ScoringService) EventPoints(event *models.Event) ([]PlayerEventPoints, error) {
+	if !event.IsComplete {
+		return s.CalculateEventPoints(event)
+	}
+
+	stored, err := s.storedEventPoints([]uint{event.ID})
+	if err != nil {
+		return nil, err
+	}
+	if stored[event.ID] == nil {
+		return []PlayerEventPoints{}, nil
+	}
+	return stored[event.ID], nil
+}
+
+// CalculateEventPoints computes the points earned by each player in an event
+// from its recorded games, using the point distribution of the event's season
+func (s *ScoringService) CalculateEventPoints(event *models.Event) ([]PlayerEventPoints, error) {
+	var season models.Season
+	if err := s.db.First(&season, event.SeasonID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load season: %w", err)
+	}
+
+	var games []models.Game
+	if err := s.db.Where("event_id = ?", event.ID).Preload("Results.Player").Find(&games).Error; err != nil {
+		return nil, fmt.Errorf("failed to load games: %w", err)
+	}
+
+	return ComputeEventPoints(season.PointDistribution, games), nil
+}
+
+// storedEventPoints loads the points stored for completed events, keyed by event ID
+func (s *ScoringService) storedEventPoints(eventIDs []uint) (map[uint][]PlayerEventPoints, error) {
+	var scores []models.EventScore
+	if err := s.db.Where("event_id IN ?", eventIDs).Preload("Player").Order("place, player_id").Find(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to load event scores: %w", err)
+	}
+
+	points := make(map[uint][]PlayerEventPoints, len(eventIDs))
+	for _, score := range scores {
+		points[score.EventID] = append(points[score.EventID], PlayerEventPoints{
+			PlayerID:    score.PlayerID,
+			PlayerName:  score.Player.Name,
+			Points:      score.Points,
+			GamesPlayed: score.GamesPlayed,
+			Place:       score.Place,
+		})
+	}
+	return points, nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"testing"
+
+	"backend/models"
+)
+
+func TestPointsForPosition(t *testing.T) {
+	custom := models.PointDistributionMap{"4": {10, 6, 3, 0}}
+
+	tests := []struct {
+		name         string
+		distribution models.PointDistributionMap
+		groupSize    int
+		position     int
+		want         float64
+	}{
+		{"default 2 player win", nil, 2, 1, 7},
+		{"default 2 player last", nil, 2, 2, 1},
+		{"default 3 player win", nil, 3, 1, 7},
+		{"default 3 player second", nil, 3, 2, 4},
+		{"default 3 player last", nil, 3, 3, 1},
+		{"default 4 player win", nil, 4, 1, 7},
+		{"default 4 player second", nil, 4, 2, 5},
+		{"default 4 player third", nil, 4, 3, 3},
+		{"default 4 player last", nil, 4, 4, 1},
+		{"custom 4 player win", custom, 4, 1, 10},
+		{"custom 4 player last", custom, 4, 4, 0},
+		{"custom falls back to default for 3 players", custom, 3, 2, 4},
+		{"position past the table", nil, 2, 3, 0},
+		{"position zero", nil, 4, 0, 0},
+		{"group size with no table", nil, 5, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PointsForPosition(tt.distribution, tt.groupSize, tt.position); got != tt.want {
+				t.Errorf("PointsForPosition(%d, %d) = %v, want %v", tt.groupSize, tt.position, got, tt.want)
+			}
+		})
+	}
+}
+
+// game builds a game whose results finish in the order the players are given
+func game(playerIDs ...uint) models.Game {
+	var g models.Game
+	for i, id := range playerIDs {
+		g.Results = append(g.Results, models.GameResult{PlayerID: id, Position: i + 1})
+	}
+	return g
+}
+
+func TestComputeEventPoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution models.PointDistributionMap
+		games        []models.Game
+		want         []PlayerEventPoints
+	}{
+		{
+			name:  "no games",
+			games: nil,
+			want:  []PlayerEventPoints{},
+		},
+		{
+			name:  "one 4 player game",
+			games: []models.Game{game(3, 1, 4, 2)},
+			want: []PlayerEventPoints{
+				{PlayerID: 3, Points: 7, GamesPlayed: 1, Place: 1},
+				{PlayerID: 1, Points: 5, GamesPlayed: 1, Place: 2},
+				{PlayerID: 4, Points: 3, GamesPlayed: 1, Place: 3},
+				{PlayerID: 2, Points: 1, GamesPlayed: 1, Place: 4},
+			},
+		},
+		{
+			name:  "points are totalled across games of different sizes",
+			games: []models.Game{game(1, 2, 3), game(2, 1), game(1, 3, 2)},
+			want: []PlayerEventPoints{
+				{PlayerID: 1, Points: 7 + 1 + 7, GamesPlayed: 3, Place: 1},
+				{PlayerID: 2, Points: 4 + 7 + 1, GamesPlayed: 3, Place: 2},
+				{PlayerID: 3, Points: 1 + 4, GamesPlayed: 2, Place: 3},
+			},
+		},
+		{
+			name:  "tied players share a place and the next place is skipped",
+			games: []models.Game{game(1, 2, 3), game(2, 1, 3)},
+			want: []PlayerEventPoints{
+				{PlayerID: 1, Points: 11, GamesPlayed: 2, Place: 1},
+				{PlayerID: 2, Points: 11, GamesPlayed: 2, Place: 1},
+				{PlayerID: 3, Points: 2, GamesPlayed: 2, Place: 3},
+			},
+		},
+		{
+			name:         "custom distribution with a default fallback",
+			distribution: models.PointDistributionMap{"4": {10, 6, 3, 0}},
+			games:        []models.Game{game(1, 2, 3, 4), game(4, 3)},
+			want: []PlayerEventPoints{
+				{PlayerID: 1, Points: 10, GamesPlayed: 1, Place: 1},
+				{PlayerID: 4, Points: 7, GamesPlayed: 2, Place: 2},
+				{PlayerID: 2, Points: 6, GamesPlayed: 1, Place: 3},
+				{PlayerID: 3, Points: 4, GamesPlayed: 2, Place: 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeEventPoints(tt.distribution, tt.games)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d players, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
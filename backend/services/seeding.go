@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+// SeededPlayer is a player's position in the seeding order for an event.
+// Value is the figure the player was seeded on: average points, standings
+// rank or IFPA rank depending on the seeding method.
+type SeededPlayer struct {
+	Seed   int           `json:"seed"`
+	Player models.Player `json:"player"`
+	Value  float64       `json:"value"`
+	Ranked bool          `json:"ranked"`
+}
+
+// Seeding is the full seeding order for an event along with the inputs that produced it
+type Seeding struct {
+	EventID uint                 `json:"eventID"`
+	Method  models.SeedingMethod `json:"method"`
+	Seed    int64                `json:"seed"`
+	Players []SeededPlayer       `json:"players"`
+}
+
+type SeedingService struct {
+	db             *gorm.DB
+	scoringService *ScoringService
+}
+
+func NewSeedingService(db *gorm.DB, scoringService *ScoringService) *SeedingService {
+	return &SeedingService{
+		db:             db,
+		scoringService: scoringService,
+	}
+}
+
+// SeedEvent orders the players checked in to an event according to the
+// event's seeding method. Players who tie, or who have nothing to be seeded
+// on, are ordered by a shuffle driven by the event's stored seed, so the same
+// event always produces the same order.
+func (s *SeedingService) SeedEvent(event *models.Event) (*Seeding, error) {
+	var players []models.Player
+	if err := s.db.Model(event).Association("Players").Find(&players); err != nil {
+		return nil, fmt.Errorf("failed to load event players: %w", err)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].ID < players[j].ID })
+
+	rng := rand.New(rand.NewSource(event.Seed))
+	rng.Shuffle(len(players), func(i, j int) { players[i], players[j] = players[j], players[i] })
+
+	seeded := make([]SeededPlayer, len(players))
+	for i, player := range players {
+		seeded[i] = SeededPlayer{Player: player}
+	}
+
+	method := event.SeedingMethod
+	if method == "" {
+		method = models.SeedingMethodAverage
+	}
+
+	switch method {
+	case models.SeedingMethodRandom:
+		// The shuffle above is the seeding
+	case models.SeedingMethodAverage:
+		if err := s.applyAverage(event, seeded); err != nil {
+			return nil, err
+		}
+		sortSeeded(seeded, true)
+	case models.SeedingMethodRank:
+		if err := s.applyStandingsRank(event, seeded); err != nil {
+			return nil, err
+		}
+		sortSeeded(seeded, false)
+	case models.SeedingMethodIFPARank:
+		applyIFPARank(seeded)
+		sortSeeded(seeded, false)
+	default:
+		return nil, fmt.Errorf("unknown seeding method %q", method)
+	}
+
+	for i := range seeded {
+		seeded[i].Seed = i + 1
+	}
+
+	return &Seeding{
+		EventID: event.ID,
+		Method:  method,
+		Seed:    event.Seed,
+		Players: seeded,
+	}, nil
+}
+
+// applyAverage seeds on average points per event attended earlier in the season
+func (s *SeedingService) applyAverage(event *models.Event, seeded []SeededPlayer) error {
+	_, priorEvents, err := s.scoringService.PriorEventPoints(event)
+	if err != nil {
+		return err
+	}
+
+	totals := make(map[uint]float64)
+	attended := make(map[uint]int)
+	for _, prior := range priorEvents {
+		for _, points := range prior.Points {
+			totals[points.PlayerID] += points.Points
+			attended[points.PlayerID]++
+		}
+	}
+
+	for i := range seeded {
+		playerID := seeded[i].Player.ID
+		if attended[playerID] > 0 {
+			seeded[i].Value = totals[playerID] / float64(attended[playerID])
+			seeded[i].Ranked = true
+		}
+	}
+	return nil
+}
+
+// applyStandingsRank seeds on season standings rank going into the event
+func (s *SeedingService) applyStandingsRank(event *models.Event, seeded []SeededPlayer) error {
+	season, priorEvents, err := s.scoringService.PriorEventPoints(event)
+	if err != nil {
+		return err
+	}
+
+	ranks := make(map[uint]int)
+	for _, row := range ComputeStandings(priorEvents, season.CountingGames) {
+		ranks[row.PlayerID] = row.Rank
+	}
+
+	for i := range seeded {
+		if rank, ok := ranks[seeded[i].Player.ID]; ok {
+			seeded[i].Value = float64(rank)
+			seeded[i].Ranked = true
+		}
+	}
+	return nil
+}
+
+// applyIFPARank seeds on the IFPA world ranking stored by the last sync.
+// Players who have not been synced yet are left unranked, so seeding never
+// waits on IFPA.
+func applyIFPARank(seeded []SeededPlayer) {
+	for i := range seeded {
+		player := seeded[i].Player
+		if player.IFPANumber != "" && player.IFPARank > 0 {
+			seeded[i].Value = float64(player.IFPARank)
+			seeded[i].Ranked = true
+		}
+	}
+}
+
+// sortSeeded orders ranked players by value, ahead of unranked players. The
+// sort is stable so ties keep their shuffled order.
+func sortSeeded(seeded []SeededPlayer, higherIsBetter bool) {
+	sort.SliceStable(seeded, func(i, j int) bool {
+		if seeded[i].Ranked != seeded[j].Ranked {
+			return seeded[i].Ranked
+		}
+		if higherIsBetter {
+			return seeded[i].Value > seeded[j].Value
+		}
+		return seeded[i].Value < seeded[j].Value
+	})
+}
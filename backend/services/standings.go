@@ -0,0 +1,219 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"backend/models"
+)
+
+// Tiebreaks applied, in order, when two players have the same season total
+const (
+	TiebreakEventWins         = "EVENT_WINS"
+	TiebreakBestFinish        = "BEST_FINISH"
+	TiebreakRegistrationOrder = "REGISTRATION_ORDER"
+)
+
+// PlayerEventResult is a player's result in one event of a season
+type PlayerEventResult struct {
+	EventID   uint      `json:"eventID"`
+	EventName string    `json:"eventName"`
+	Date      time.Time `json:"date"`
+	Points    float64   `json:"points"`
+	Place     int       `json:"place"`
+}
+
+// StandingRow is a player's position in the season standings
+type StandingRow struct {
+	Rank          int                 `json:"rank"`
+	PlayerID      uint                `json:"playerID"`
+	PlayerName    string              `json:"playerName"`
+	Total         float64             `json:"total"`
+	EventsPlayed  int                 `json:"eventsPlayed"`
+	CountedEvents []PlayerEventResult `json:"countedEvents"`
+	DroppedEvents []PlayerEventResult `json:"droppedEvents"`
+	EventWins     int                 `json:"eventWins"`
+	BestFinish    int                 `json:"bestFinish"`
+	// Tiebreak names the rule that separated this player from a player with the same total
+	Tiebreak string `json:"tiebreak,omitempty"`
+}
+
+// SeasonEventPoints pairs an event with the points each player earned in it
+type SeasonEventPoints struct {
+	Event  models.Event
+	Points []PlayerEventPoints
+}
+
+// ComputeStandings totals each player's best countingGames event results and
+// ranks the players. A countingGames of zero or less counts every event.
+func ComputeStandings(events []SeasonEventPoints, countingGames int) []StandingRow {
+	rows := make(map[uint]*StandingRow)
+	results := make(map[uint][]PlayerEventResult)
+	for _, event := range events {
+		for _, points := range event.Points {
+			row, ok := rows[points.PlayerID]
+			if !ok {
+				row = &StandingRow{
+					PlayerID:   points.PlayerID,
+					PlayerName: points.PlayerName,
+				}
+				rows[points.PlayerID] = row
+			}
+			row.EventsPlayed++
+			if points.Place == 1 {
+				row.EventWins++
+			}
+			if row.BestFinish == 0 || points.Place < row.BestFinish {
+				row.BestFinish = points.Place
+			}
+			results[points.PlayerID] = append(results[points.PlayerID], PlayerEventResult{
+				EventID:   event.Event.ID,
+				EventName: event.Event.Name,
+				Date:      event.Event.Date,
+				Points:    points.Points,
+				Place:     points.Place,
+			})
+		}
+	}
+
+	standings := make([]StandingRow, 0, len(rows))
+	for playerID, row := range rows {
+		playerResults := results[playerID]
+		sort.SliceStable(playerResults, func(i, j int) bool {
+			if playerResults[i].Points != playerResults[j].Points {
+				return playerResults[i].Points > playerResults[j].Points
+			}
+			return playerResults[i].Date.Before(playerResults[j].Date)
+		})
+
+		counted := len(playerResults)
+		if countingGames > 0 && countingGames < counted {
+			counted = countingGames
+		}
+		row.CountedEvents = playerResults[:counted]
+		row.DroppedEvents = append([]PlayerEventResult{}, playerResults[counted:]...)
+		for _, result := range row.CountedEvents {
+			row.Total += result.Points
+		}
+		standings = append(standings, *row)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Total != standings[j].Total {
+			return standings[i].Total > standings[j].Total
+		}
+		return tiebreak(&standings[i], &standings[j]) < 0
+	})
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if i > 0 && standings[i].Total == standings[i-1].Total {
+			rule := tiebreakRule(&standings[i-1], &standings[i])
+			standings[i].Tiebreak = rule
+			if standings[i-1].Tiebreak == "" {
+				standings[i-1].Tiebreak = rule
+			}
+		}
+	}
+
+	return standings
+}
+
+// tiebreak orders two players with equal totals, returning a negative number
+// when a should be ranked ahead of b
+func tiebreak(a, b *StandingRow) int {
+	if a.EventWins != b.EventWins {
+		return b.EventWins - a.EventWins
+	}
+	if a.BestFinish != b.BestFinish {
+		return a.BestFinish - b.BestFinish
+	}
+	if a.PlayerID < b.PlayerID {
+		return -1
+	}
+	if a.PlayerID > b.PlayerID {
+		return 1
+	}
+	return 0
+}
+
+// tiebreakRule reports which tiebreak separated two players with equal totals
+func tiebreakRule(a, b *StandingRow) string {
+	if a.EventWins != b.EventWins {
+		return TiebreakEventWins
+	}
+	if a.BestFinish != b.BestFinish {
+		return TiebreakBestFinish
+	}
+	return TiebreakRegistrationOrder
+}
+
+// SeasonStandings computes the standings for a season from the stored results
+// of its completed regular (non-finals) events
+func (s *ScoringService) SeasonStandings(seasonID uint) ([]StandingRow, error) {
+	var season models.Season
+	if err := s.db.First(&season, seasonID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load season: %w", err)
+	}
+
+	var events []models.Event
+	if err := s.db.Where("season_id = ? AND is_finals = ? AND is_complete = ?", season.ID, false, true).
+		Order("date").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	seasonPoints, err := s.seasonEventPoints(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return ComputeStandings(seasonPoints, season.CountingGames), nil
+}
+
+// PriorEventPoints returns the points earned in each completed regular event
+// of the event's season that was held before it, along with the season itself
+func (s *ScoringService) PriorEventPoints(event *models.Event) (*models.Season, []SeasonEventPoints, error) {
+	var season models.Season
+	if err := s.db.First(&season, event.SeasonID).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load season: %w", err)
+	}
+
+	var events []models.Event
+	if err := s.db.Where("season_id = ? AND is_finals = ? AND is_complete = ? AND date < ? AND id <> ?", season.ID, false, true, event.Date, event.ID).
+		Order("date").Find(&events).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	seasonPoints, err := s.seasonEventPoints(events)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &season, seasonPoints, nil
+}
+
+// seasonEventPoints pairs each completed event with its stored points
+func (s *ScoringService) seasonEventPoints(events []models.Event) ([]SeasonEventPoints, error) {
+	if len(events) == 0 {
+		return []SeasonEventPoints{}, nil
+	}
+
+	eventIDs := make([]uint, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+
+	stored, err := s.storedEventPoints(eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonPoints := make([]SeasonEventPoints, len(events))
+	for i, event := range events {
+		seasonPoints[i] = SeasonEventPoints{
+			Event:  event,
+			Points: stored[event.ID],
+		}
+	}
+	return seasonPoints, nil
+}
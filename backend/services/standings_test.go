@@ -0,0 +1,155 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/models"
+)
+
+// seasonEvent builds a completed event held the given number of days into the
+// season, with each player's points and place in it
+func seasonEvent(id uint, day int, points ...PlayerEventPoints) SeasonEventPoints {
+	return SeasonEventPoints{
+		Event: models.Event{
+			Model: gorm.Model{ID: id},
+			Name:  "Week",
+			Date:  time.Date(2024, 3, 1+day, 0, 0, 0, 0, time.UTC),
+		},
+		Points: points,
+	}
+}
+
+func TestComputeStandingsCountsBestEvents(t *testing.T) {
+	events := []SeasonEventPoints{
+		seasonEvent(1, 0, PlayerEventPoints{PlayerID: 1, Points: 10, Place: 2}),
+		seasonEvent(2, 7, PlayerEventPoints{PlayerID: 1, Points: 30, Place: 1}),
+		seasonEvent(3, 14, PlayerEventPoints{PlayerID: 1, Points: 20, Place: 1}),
+		seasonEvent(4, 21, PlayerEventPoints{PlayerID: 1, Points: 20, Place: 2}),
+	}
+
+	tests := []struct {
+		name          string
+		countingGames int
+		wantTotal     float64
+		wantCounted   []uint
+		wantDropped   []uint
+	}{
+		{"best two", 2, 50, []uint{2, 3}, []uint{4, 1}},
+		{"best three keep the earlier of two equal events", 3, 70, []uint{2, 3, 4}, []uint{1}},
+		{"more counting games than events", 10, 80, []uint{2, 3, 4, 1}, nil},
+		{"zero counts every event", 0, 80, []uint{2, 3, 4, 1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := ComputeStandings(events, tt.countingGames)
+			if len(standings) != 1 {
+				t.Fatalf("got %d rows, want 1", len(standings))
+			}
+			row := standings[0]
+			if row.Total != tt.wantTotal {
+				t.Errorf("total = %v, want %v", row.Total, tt.wantTotal)
+			}
+			if row.EventsPlayed != 4 || row.EventWins != 2 || row.BestFinish != 1 {
+				t.Errorf("played %d, wins %d, best %d; want 4, 2, 1", row.EventsPlayed, row.EventWins, row.BestFinish)
+			}
+			if got := resultEventIDs(row.CountedEvents); !equalIDs(got, tt.wantCounted) {
+				t.Errorf("counted events = %v, want %v", got, tt.wantCounted)
+			}
+			if got := resultEventIDs(row.DroppedEvents); !equalIDs(got, tt.wantDropped) {
+				t.Errorf("dropped events = %v, want %v", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestComputeStandingsTiebreaks(t *testing.T) {
+	tests := []struct {
+		name         string
+		events       []SeasonEventPoints
+		wantOrder    []uint
+		wantTiebreak string
+	}{
+		{
+			name: "higher total wins without a tiebreak",
+			events: []SeasonEventPoints{
+				seasonEvent(1, 0, PlayerEventPoints{PlayerID: 1, Points: 10, Place: 2}, PlayerEventPoints{PlayerID: 2, Points: 12, Place: 1}),
+			},
+			wantOrder: []uint{2, 1},
+		},
+		{
+			name: "event wins",
+			events: []SeasonEventPoints{
+				seasonEvent(1, 0, PlayerEventPoints{PlayerID: 1, Points: 10, Place: 2}, PlayerEventPoints{PlayerID: 2, Points: 12, Place: 1}),
+				seasonEvent(2, 7, PlayerEventPoints{PlayerID: 1, Points: 12, Place: 2}, PlayerEventPoints{PlayerID: 2, Points: 10, Place: 3}),
+			},
+			wantOrder:    []uint{2, 1},
+			wantTiebreak: TiebreakEventWins,
+		},
+		{
+			name: "best finish when event wins are level",
+			events: []SeasonEventPoints{
+				seasonEvent(1, 0, PlayerEventPoints{PlayerID: 1, Points: 10, Place: 3}, PlayerEventPoints{PlayerID: 2, Points: 12, Place: 2}),
+				seasonEvent(2, 7, PlayerEventPoints{PlayerID: 1, Points: 12, Place: 3}, PlayerEventPoints{PlayerID: 2, Points: 10, Place: 4}),
+			},
+			wantOrder:    []uint{2, 1},
+			wantTiebreak: TiebreakBestFinish,
+		},
+		{
+			name: "registration order when everything else is level",
+			events: []SeasonEventPoints{
+				seasonEvent(1, 0, PlayerEventPoints{PlayerID: 2, Points: 10, Place: 1}, PlayerEventPoints{PlayerID: 1, Points: 10, Place: 1}),
+			},
+			wantOrder:    []uint{1, 2},
+			wantTiebreak: TiebreakRegistrationOrder,
+		},
+		{
+			name: "event wins before best finish when both players have won",
+			events: []SeasonEventPoints{
+				seasonEvent(1, 0, PlayerEventPoints{PlayerID: 1, Points: 5, Place: 1}, PlayerEventPoints{PlayerID: 2, Points: 6, Place: 1}),
+				seasonEvent(2, 7, PlayerEventPoints{PlayerID: 1, Points: 5, Place: 1}, PlayerEventPoints{PlayerID: 2, Points: 4, Place: 2}),
+			},
+			wantOrder:    []uint{1, 2},
+			wantTiebreak: TiebreakEventWins,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := ComputeStandings(tt.events, 0)
+			if len(standings) != len(tt.wantOrder) {
+				t.Fatalf("got %d rows, want %d", len(standings), len(tt.wantOrder))
+			}
+			for i, playerID := range tt.wantOrder {
+				row := standings[i]
+				if row.PlayerID != playerID || row.Rank != i+1 {
+					t.Errorf("rank %d = player %d (rank %d), want player %d", i+1, row.PlayerID, row.Rank, playerID)
+				}
+				if row.Tiebreak != tt.wantTiebreak {
+					t.Errorf("player %d tiebreak = %q, want %q", row.PlayerID, row.Tiebreak, tt.wantTiebreak)
+				}
+			}
+		})
+	}
+}
+
+func resultEventIDs(results []PlayerEventResult) []uint {
+	var ids []uint
+	for _, result := range results {
+		ids = append(ids, result.EventID)
+	}
+	return ids
+}
+
+func equalIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
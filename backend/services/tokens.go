@@ -0,0 +1,178 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidRefreshToken is returned for a refresh token that is unknown,
+	// expired or revoked
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when a refresh token that was already
+	// exchanged is presented again. Its whole family is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// TokenConfig configures a TokenService. An empty RefreshTTL falls back to
+// 30 days.
+type TokenConfig struct {
+	RefreshTTL time.Duration
+}
+
+// TokenService issues and rotates refresh tokens and keeps the list of
+// revoked access tokens
+type TokenService struct {
+	db         *gorm.DB
+	refreshTTL time.Duration
+}
+
+func NewTokenService(db *gorm.DB, config TokenConfig) *TokenService {
+	if config.RefreshTTL <= 0 {
+		config.RefreshTTL = 30 * 24 * time.Hour
+	}
+
+	return &TokenService{
+		db:         db,
+		refreshTTL: config.RefreshTTL,
+	}
+}
+
+// IssueRefreshToken starts a new token family for the user, as on login
+func (s *TokenService) IssueRefreshToken(userID uint) (string, error) {
+	familyID, err := randomToken(16)
+	if err != nil {
+		return "", err
+	}
+	return s.createRefreshToken(s.db, userID, familyID)
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one in the same
+// family and returns the new token and the user it belongs to. Each token can
+// be exchanged once; presenting it again revokes the family and returns
+// ErrRefreshTokenReused.
+func (s *TokenService) RotateRefreshToken(raw string) (string, uint, error) {
+	var current models.RefreshToken
+	var next string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		lookup := tx.Where("token_hash = ?", hashToken(raw)).Limit(1).Find(&current)
+		if lookup.Error != nil {
+			return lookup.Error
+		}
+		if lookup.RowsAffected == 0 || time.Now().After(current.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		// Claiming the token with a conditional update means two requests
+		// racing with the same token cannot both succeed
+		claim := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", current.ID).
+			Update("revoked_at", time.Now())
+		if claim.Error != nil {
+			return claim.Error
+		}
+		if claim.RowsAffected == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		var err error
+		next, err = s.createRefreshToken(tx, current.UserID, current.FamilyID)
+		return err
+	})
+	if errors.Is(err, ErrRefreshTokenReused) {
+		if revokeErr := s.revokeFamily(current.FamilyID); revokeErr != nil {
+			return "", 0, revokeErr
+		}
+		return "", 0, ErrRefreshTokenReused
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	return next, current.UserID, nil
+}
+
+// RevokeRefreshToken revokes the family of the user's refresh token, as on
+// logout. Tokens that are unknown or belong to someone else are ignored.
+func (s *TokenService) RevokeRefreshToken(userID uint, raw string) error {
+	var token models.RefreshToken
+	lookup := s.db.Where("token_hash = ? AND user_id = ?", hashToken(raw), userID).Limit(1).Find(&token)
+	if lookup.Error != nil {
+		return fmt.Errorf("failed to load refresh token: %w", lookup.Error)
+	}
+	if lookup.RowsAffected == 0 {
+		return nil
+	}
+	return s.revokeFamily(token.FamilyID)
+}
+
+// RevokeAccessToken refuses the access token with the given jti until it
+// expires. Entries for tokens that have since expired are cleared out.
+func (s *TokenService) RevokeAccessToken(jti string, userID uint, expiresAt time.Time) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&models.RevokedAccessToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Save(&models.RevokedAccessToken{JTI: jti, UserID: userID, ExpiresAt: expiresAt}).Error
+	})
+}
+
+// AccessTokenRevoked reports whether the access token with the given jti has
+// been revoked
+func (s *TokenService) AccessTokenRevoked(jti string) (bool, error) {
+	var count int64
+	if err := s.db.Model(&models.RevokedAccessToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *TokenService) createRefreshToken(tx *gorm.DB, userID uint, familyID string) (string, error) {
+	raw, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	token := models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(raw),
+		ExpiresAt: time.Now().Add(s.refreshTTL),
+	}
+	if err := tx.Create(&token).Error; err != nil {
+		return "", fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return raw, nil
+}
+
+func (s *TokenService) revokeFamily(familyID string) error {
+	return s.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// NewTokenID returns a random identifier for a token's jti claim
+func NewTokenID() (string, error) {
+	return randomToken(16)
+}
+
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"backend/models"
+)
+
+func newTestTokenService(t *testing.T) *TokenService {
+	t.Helper()
+	db := newTestDB(t)
+	if err := db.AutoMigrate(&models.RefreshToken{}, &models.RevokedAccessToken{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewTokenService(db, TokenConfig{})
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	service := newTestTokenService(t)
+	first, err := service.IssueRefreshToken(7)
+	if err != nil {
+		t.Fatalf("IssueRefreshToken: %v", err)
+	}
+
+	second, userID, err := service.RotateRefreshToken(first)
+	if err != nil {
+		t.Fatalf("RotateRefreshToken: %v", err)
+	}
+	if userID != 7 || second == "" || second == first {
+		t.Errorf("rotation returned token %q for user %d", second, userID)
+	}
+
+	third, _, err := service.RotateRefreshToken(second)
+	if err != nil {
+		t.Fatalf("rotating the new token: %v", err)
+	}
+
+	var tokens []models.RefreshToken
+	service.db.Order("id").Find(&tokens)
+	if len(tokens) != 3 || tokens[0].FamilyID != tokens[2].FamilyID {
+		t.Fatalf("expected three tokens in one family, got %+v", tokens)
+	}
+	if tokens[0].RevokedAt == nil || tokens[1].RevokedAt == nil || tokens[2].RevokedAt != nil {
+		t.Errorf("only the latest token should still be usable: %+v", tokens)
+	}
+	if tokens[2].TokenHash == third {
+		t.Error("refresh tokens should be stored hashed")
+	}
+}
+
+func TestRotateRefreshTokenReuseRevokesFamily(t *testing.T) {
+	service := newTestTokenService(t)
+	stolen, _ := service.IssueRefreshToken(7)
+	other, _ := service.IssueRefreshToken(7)
+
+	latest, _, err := service.RotateRefreshToken(stolen)
+	if err != nil {
+		t.Fatalf("RotateRefreshToken: %v", err)
+	}
+
+	if _, _, err := service.RotateRefreshToken(stolen); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("reusing a token: error = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, _, err := service.RotateRefreshToken(latest); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("token issued after the stolen one: error = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, _, err := service.RotateRefreshToken(other); err != nil {
+		t.Errorf("a separate login should be unaffected: %v", err)
+	}
+}
+
+func TestRotateRefreshTokenRejectsUnknownAndExpired(t *testing.T) {
+	service := newTestTokenService(t)
+
+	if _, _, err := service.RotateRefreshToken("not-a-token"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("unknown token: error = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	expired, _ := service.IssueRefreshToken(7)
+	service.db.Model(&models.RefreshToken{}).Where("token_hash = ?", hashToken(expired)).
+		Update("expires_at", time.Now().Add(-time.Minute))
+	if _, _, err := service.RotateRefreshToken(expired); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expired token: error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+	service := newTestTokenService(t)
+	token, _ := service.IssueRefreshToken(7)
+
+	// Someone else's token is ignored
+	if err := service.RevokeRefreshToken(8, token); err != nil {
+		t.Fatalf("RevokeRefreshToken: %v", err)
+	}
+	rotated, _, err := service.RotateRefreshToken(token)
+	if err != nil {
+		t.Fatalf("token revoked by another user: %v", err)
+	}
+
+	if err := service.RevokeRefreshToken(7, rotated); err != nil {
+		t.Fatalf("RevokeRefreshToken: %v", err)
+	}
+	if _, _, err := service.RotateRefreshToken(rotated); err == nil {
+		t.Error("expected a revoked token to be refused")
+	}
+}
+
+func TestAccessTokenRevoked(t *testing.T) {
+	service := newTestTokenService(t)
+
+	if err := service.RevokeAccessToken("old", 7, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("RevokeAccessToken: %v", err)
+	}
+	if err := service.RevokeAccessToken("current", 7, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeAccessToken: %v", err)
+	}
+
+	tests := []struct {
+		jti  string
+		want bool
+	}{
+		{"current", true},
+		{"old", false}, // Expired entries are cleared when the next token is revoked
+		{"other", false},
+	}
+	for _, tt := range tests {
+		revoked, err := service.AccessTokenRevoked(tt.jti)
+		if err != nil {
+			t.Fatalf("AccessTokenRevoked: %v", err)
+		}
+		if revoked != tt.want {
+			t.Errorf("AccessTokenRevoked(%q) = %v, want %v", tt.jti, revoked, tt.want)
+		}
+	}
+}